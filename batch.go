@@ -0,0 +1,100 @@
+package bls
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// SignatureSet is a signature with the public key and message it is
+// verified against.
+type SignatureSet struct {
+	PublicKey *PublicKey
+	Message   []byte
+	Signature *Signature
+	Domain    uint64
+}
+
+// batchWeights reads a nonzero random weight of the given size for each
+// signature set.
+func batchWeights(n int, weightBits int, r io.Reader) ([]*big.Int, error) {
+	if weightBits != 64 && weightBits != 128 {
+		return nil, errors.New("batch weights must be 64 or 128 bits")
+	}
+	buf := make([]byte, weightBits/8)
+	weights := make([]*big.Int, n)
+	for i := range weights {
+		for {
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return nil, err
+			}
+			weights[i] = new(big.Int).SetBytes(buf)
+			if weights[i].Sign() != 0 {
+				break
+			}
+		}
+	}
+	return weights, nil
+}
+
+// BatchVerify verifies many signatures at once. Each set is weighted with
+// a random scalar of weightBits (64 or 128) bits read from r, and all sets
+// are checked with a single multi-pairing:
+//
+//	e(sum r_i * sig_i, -g2) * prod e(r_i * H(m_i), pub_i) == 1
+//
+// The random weights stop invalid signatures from cancelling each other
+// out, so the batch is valid only if every signature is valid, except with
+// probability 2^-weightBits. The signatures must be in G1, which
+// DeserializeSignature checks. An empty batch is valid.
+func BatchVerify(sets []SignatureSet, weightBits int, r io.Reader) (bool, error) {
+	weights, err := batchWeights(len(sets), weightBits, r)
+	if err != nil {
+		return false, err
+	}
+	if len(sets) == 0 {
+		return true, nil
+	}
+
+	sig := G1ProjectiveZero.Copy()
+	g1s := make([]*G1Projective, len(sets)+1)
+	g2s := make([]*G2Prepared, len(sets)+1)
+	for i, set := range sets {
+		sig = sig.Add(set.Signature.s.Mul(weights[i]))
+		g1s[i+1] = HashG1(set.Message, set.Domain).Mul(weights[i])
+		g2s[i+1] = G2AffineToPrepared(set.PublicKey.p.ToAffine())
+	}
+	g1s[0] = sig
+	g2s[0] = g2PreparedNegOne
+	return pairingCheckPrepared(g1s, g2s), nil
+}
+
+// BatchVerifyBisect verifies many signatures like BatchVerify and returns
+// the indices of the invalid ones in increasing order, or nil if all of
+// them are valid. When a batch fails, it is split in half and each half is
+// checked with new weights until the invalid signatures are isolated, so
+// a few invalid signatures cost a few batches on the path to each of them.
+func BatchVerifyBisect(sets []SignatureSet, weightBits int, r io.Reader) ([]int, error) {
+	var invalid []int
+	var bisect func(start int, end int) error
+	bisect = func(start int, end int) error {
+		valid, err := BatchVerify(sets[start:end], weightBits, r)
+		if err != nil || valid {
+			return err
+		}
+		if end-start == 1 {
+			invalid = append(invalid, start)
+			return nil
+		}
+		mid := (start + end) / 2
+		if err := bisect(start, mid); err != nil {
+			return err
+		}
+		return bisect(mid, end)
+	}
+
+	if err := bisect(0, len(sets)); err != nil {
+		return nil, err
+	}
+	return invalid, nil
+}
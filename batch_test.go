@@ -0,0 +1,135 @@
+package bls_test
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+func batchSignatureSets(n int, seed uint64) []bls.SignatureSet {
+	r := NewXORShift(seed)
+	sets := make([]bls.SignatureSet, n)
+	for i := range sets {
+		priv, _ := bls.RandKey(r)
+		msg := []byte(fmt.Sprintf("Hello world! 16 characters %d", i))
+		sets[i] = bls.SignatureSet{
+			PublicKey: bls.PrivToPub(priv),
+			Message:   msg,
+			Signature: bls.Sign(msg, priv, uint64(i%3)),
+			Domain:    uint64(i % 3),
+		}
+	}
+	return sets
+}
+
+func TestBatchVerify(t *testing.T) {
+	r := NewXORShift(1)
+	sets := batchSignatureSets(10, 2)
+
+	for _, weightBits := range []int{64, 128} {
+		valid, err := bls.BatchVerify(sets, weightBits, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !valid {
+			t.Fatalf("valid batch with %d bit weights did not verify", weightBits)
+		}
+	}
+
+	valid, err := bls.BatchVerify(nil, 64, r)
+	if err != nil || !valid {
+		t.Fatal("empty batch did not verify")
+	}
+
+	if _, err := bls.BatchVerify(sets, 32, r); err == nil {
+		t.Fatal("32 bit weights were accepted")
+	}
+
+	sets[4].Domain++
+	valid, err = bls.BatchVerify(sets, 64, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid {
+		t.Fatal("batch with an invalid signature verified")
+	}
+}
+
+func TestBatchVerifyCancellingSignatures(t *testing.T) {
+	r := NewXORShift(3)
+	sets := batchSignatureSets(6, 4)
+
+	// adding d to one signature and -d to another keeps the aggregate
+	// signature the same, which only the random weights detect
+	msg := []byte("cancelling signatures")
+	d := bls.Sign(msg, bls.KeyFromBig(big.NewInt(5)), 0)
+	negD := bls.Sign(msg, bls.KeyFromBig(big.NewInt(-5)), 0)
+	sets[1].Signature = sets[1].Signature.Copy()
+	sets[1].Signature.Aggregate(d)
+	sets[3].Signature = sets[3].Signature.Copy()
+	sets[3].Signature.Aggregate(negD)
+
+	valid, err := bls.BatchVerify(sets, 64, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid {
+		t.Fatal("batch with cancelling signatures verified")
+	}
+
+	invalid, err := bls.BatchVerifyBisect(sets, 64, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(invalid, []int{1, 3}) {
+		t.Fatalf("BatchVerifyBisect returned %v, expected [1 3]", invalid)
+	}
+}
+
+func TestBatchVerifyBisect(t *testing.T) {
+	r := NewXORShift(5)
+	sets := batchSignatureSets(13, 6)
+
+	invalid, err := bls.BatchVerifyBisect(sets, 128, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invalid != nil {
+		t.Fatalf("BatchVerifyBisect returned %v for a valid batch", invalid)
+	}
+
+	for _, i := range []int{0, 7, 8, 12} {
+		sets[i].Message = []byte("wrong message")
+	}
+	invalid, err = bls.BatchVerifyBisect(sets, 128, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(invalid, []int{0, 7, 8, 12}) {
+		t.Fatalf("BatchVerifyBisect returned %v, expected [0 7 8 12]", invalid)
+	}
+}
+
+func BenchmarkBatchVerify64(b *testing.B) {
+	r := NewXORShift(7)
+	sets := batchSignatureSets(64, 8)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bls.BatchVerify(sets, 64, r)
+	}
+}
+
+func BenchmarkVerify64(b *testing.B) {
+	sets := batchSignatureSets(64, 8)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, set := range sets {
+			bls.Verify(set.Message, set.PublicKey, set.Signature, set.Domain)
+		}
+	}
+}
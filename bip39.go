@@ -0,0 +1,122 @@
+package bls
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// This file implements BIP-39 mnemonics with the English wordlist. The
+// seed of a mnemonic is turned into a secret key with DeriveMasterSK, or
+// into a key tree with DeriveSKFromPath.
+
+// MnemonicEntropySize is the entropy of a 24 word mnemonic in bytes.
+const MnemonicEntropySize = 32
+
+// bip39Iterations is the PBKDF2 iteration count of the seed.
+const bip39Iterations = 2048
+
+var bip39Words = strings.Fields(bip39English)
+
+var bip39Indices = func() map[string]int {
+	m := make(map[string]int, len(bip39Words))
+	for i, w := range bip39Words {
+		m[w] = i
+	}
+	return m
+}()
+
+// NewMnemonic generates a 24 word mnemonic with entropy read from r.
+func NewMnemonic(r io.Reader) (string, error) {
+	var entropy [MnemonicEntropySize]byte
+	if _, err := io.ReadFull(r, entropy[:]); err != nil {
+		return "", err
+	}
+	return MnemonicFromEntropy(entropy[:])
+}
+
+// MnemonicFromEntropy encodes entropy of 16, 20, 24, 28 or 32 bytes as a
+// mnemonic of 12 to 24 words.
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 {
+		return "", errors.New("mnemonic entropy must be 16, 20, 24, 28 or 32 bytes")
+	}
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+
+	// every word encodes 11 bits of the entropy followed by the checksum
+	words := make([]string, len(entropy)*8/32*3)
+	for i := range words {
+		index := 0
+		for j := 0; j < 11; j++ {
+			bit := i*11 + j
+			index = index<<1 | int(data[bit/8]>>(7-uint(bit%8))&1)
+		}
+		words[i] = bip39Words[index]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy decodes a mnemonic and checks its checksum.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, errors.New("mnemonic must have 12, 15, 18, 21 or 24 words")
+	}
+
+	data := make([]byte, (len(words)*11+7)/8)
+	for i, w := range words {
+		index, ok := bip39Indices[w]
+		if !ok {
+			return nil, fmt.Errorf("%q is not in the wordlist", w)
+		}
+		for j := 0; j < 11; j++ {
+			if index>>(10-uint(j))&1 != 0 {
+				bit := i*11 + j
+				data[bit/8] |= 1 << (7 - uint(bit%8))
+			}
+		}
+	}
+
+	entropy := data[:len(words)*4/3]
+	checksumBits := uint(len(words) / 3)
+	checksum := sha256.Sum256(entropy)
+	if checksum[0]>>(8-checksumBits) != data[len(entropy)]>>(8-checksumBits) {
+		return nil, errors.New("mnemonic checksum is invalid")
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic checks that a mnemonic only uses words of the wordlist
+// and has a valid checksum.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// MnemonicToSeed validates a mnemonic and derives its 64 byte seed with
+// PBKDF2-HMAC-SHA512. The passphrase may be empty.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	password := strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(password), []byte(salt), bip39Iterations, 64, sha512.New), nil
+}
+
+// MnemonicToSecretKey derives the EIP-2333 master secret key of a
+// mnemonic and passphrase.
+func MnemonicToSecretKey(mnemonic string, passphrase string) (*SecretKey, error) {
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return DeriveMasterSK(seed)
+}
@@ -0,0 +1,131 @@
+package bls_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+type mnemonicVector struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}
+
+// test vectors of BIP-39, with the passphrase TREZOR
+var mnemonicVectors = []mnemonicVector{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"ffffffffffffffffffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo when",
+		"0cd6e5d827bb62eb8fc1e262254223817fd068a74b5b449cc2f667c3f1f985a76379b43348d952e2265b4cd129090758b3e3c2c49103b5051aac2eaeb890a528",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	},
+	{
+		"3e141609b97933b66a060dcddc71fad1d91677db872031e85f4c015c5e7e8982",
+		"dignity pass list indicate nasty swamp pool script soccer toe leaf photo multiply desk host tomato cradle drill spread actor shine dismiss champion exotic",
+		"ff7f3184df8696d8bef94b6c03114dbee0ef89ff938712301d27ed8336ca89ef9635da20af07d4175f2bf5f3de130f39c9d9e8dd0472489c19b1a020a940da67",
+	},
+	{
+		"15da872c95a13dd738fbf50e427583ad61f18fd99f628c417a61cf8343c90419",
+		"beyond stage sleep clip because twist token leaf atom beauty genius food business side grid unable middle armed observe pair crouch tonight away coconut",
+		"b15509eaa2d09d3efd3e006ef42151b30367dc6e3aa5e44caba3fe4d3e352e65101fbdb86a96776b91946ff06f8eac594dc6ee1d3e82a42dfe1b40fef6bcc3fd",
+	},
+}
+
+func TestMnemonicVectors(t *testing.T) {
+	for _, v := range mnemonicVectors {
+		entropy := mustDecodeHex(v.entropy)
+		mnemonic, err := bls.MnemonicFromEntropy(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mnemonic != v.mnemonic {
+			t.Fatalf("MnemonicFromEntropy(%s) returned %q, expected %q", v.entropy, mnemonic, v.mnemonic)
+		}
+
+		decoded, err := bls.MnemonicToEntropy(v.mnemonic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded, entropy) {
+			t.Fatalf("MnemonicToEntropy returned %x, expected %s", decoded, v.entropy)
+		}
+
+		seed, err := bls.MnemonicToSeed(v.mnemonic, "TREZOR")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(seed) != v.seed {
+			t.Fatalf("MnemonicToSeed returned %x, expected %s", seed, v.seed)
+		}
+	}
+}
+
+func TestInvalidMnemonics(t *testing.T) {
+	invalid := []string{
+		// too few words
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"dignity pass list indicate nasty",
+		// bad checksum
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon letter",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo why",
+		"jello better achieve collect unaware mountain thought cargo oxygen act hood bridge",
+		// not in the wordlist
+		"letter advice cage absurd amount doctor acoustic avoid letter advice caged above",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo, wrong",
+	}
+	for _, m := range invalid {
+		if err := bls.ValidateMnemonic(m); err == nil {
+			t.Fatalf("invalid mnemonic %q was accepted", m)
+		}
+		if _, err := bls.MnemonicToSeed(m, ""); err == nil {
+			t.Fatalf("seed was derived from invalid mnemonic %q", m)
+		}
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	r := NewXORShift(1)
+	for i := 0; i < 10; i++ {
+		mnemonic, err := bls.NewMnemonic(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(strings.Fields(mnemonic)) != 24 {
+			t.Fatalf("mnemonic %q does not have 24 words", mnemonic)
+		}
+		if err := bls.ValidateMnemonic(mnemonic); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMnemonicToSecretKey(t *testing.T) {
+	// the seed of the first EIP-2333 vector is the first BIP-39 vector
+	sk, err := bls.MnemonicToSecretKey(mnemonicVectors[0].mnemonic, "TREZOR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := new(big.Int).SetString("6083874454709270928345386274498605044986640685124978867557563392430687146096", 10)
+	if !bytes.Equal(sk.Serialize(), bls.KeyFromBig(expected).Serialize()) {
+		t.Fatalf("MnemonicToSecretKey returned %x, expected %x", sk.Serialize(), expected.Bytes())
+	}
+}
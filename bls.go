@@ -2,6 +2,7 @@ package bls
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"log"
 	"math/big"
@@ -60,10 +61,10 @@ func (p PublicKey) SerializeBig() [193]byte {
 	affine := p.p.ToAffine()
 	out := [193]byte{}
 	infinity := affine.infinity
-	copy(out[1:49], affine.x.c0.n.Bytes())
-	copy(out[49:97], affine.x.c1.n.Bytes())
-	copy(out[97:145], affine.y.c0.n.Bytes())
-	copy(out[145:193], affine.y.c1.n.Bytes())
+	copy(out[1:49], affine.x.c0.ToBig().Bytes())
+	copy(out[49:97], affine.x.c1.ToBig().Bytes())
+	copy(out[97:145], affine.y.c0.ToBig().Bytes())
+	copy(out[145:193], affine.y.c1.ToBig().Bytes())
 
 	if infinity {
 		out[0] = 1
@@ -79,18 +80,14 @@ func DeserializePublicKeyBig(bigPublicKey [193]byte) *PublicKey {
 		g.infinity = true
 		return &PublicKey{p: g.ToProjective()}
 	}
-	g.x = &FQ2{
-		c0: &FQ{n: new(big.Int)},
-		c1: &FQ{n: new(big.Int)},
-	}
-	g.y = &FQ2{
-		c0: &FQ{n: new(big.Int)},
-		c1: &FQ{n: new(big.Int)},
-	}
-	g.x.c0.n.SetBytes(bigPublicKey[1:49])
-	g.x.c1.n.SetBytes(bigPublicKey[49:97])
-	g.y.c0.n.SetBytes(bigPublicKey[97:145])
-	g.y.c1.n.SetBytes(bigPublicKey[145:193])
+	g.x = NewFQ2(
+		NewFQ(new(big.Int).SetBytes(bigPublicKey[1:49])),
+		NewFQ(new(big.Int).SetBytes(bigPublicKey[49:97])),
+	)
+	g.y = NewFQ2(
+		NewFQ(new(big.Int).SetBytes(bigPublicKey[97:145])),
+		NewFQ(new(big.Int).SetBytes(bigPublicKey[145:193])),
+	)
 	return &PublicKey{p: g.ToProjective()}
 }
 
@@ -121,24 +118,30 @@ func (s SecretKey) String() string {
 
 // Serialize serializes a secret key to bytes.
 func (s SecretKey) Serialize() []byte {
-	return s.f.ToBig().Bytes()
+	b := s.f.Bytes()
+	return b[:]
 }
 
 // DeserializeSecretKey deserializes a secret key from
 // bytes.
 func DeserializeSecretKey(b []byte) *SecretKey {
-	return &SecretKey{NewFR(new(big.Int).SetBytes(b))}
+	if len(b) > 32 {
+		return &SecretKey{NewFR(new(big.Int).SetBytes(b))}
+	}
+	var k [32]byte
+	copy(k[32-len(b):], b)
+	return &SecretKey{FRReprToFR(FRReprFromBytes(k))}
 }
 
 // Sign signs a message with a secret key.
 func Sign(message []byte, key *SecretKey, domain uint64) *Signature {
-	h := HashG1(message, domain).Mul(key.f.n)
+	h := HashG1(message, domain).MulFR(key.f)
 	return &Signature{s: h}
 }
 
 // PrivToPub converts the private key into a public key.
 func PrivToPub(k *SecretKey) *PublicKey {
-	return &PublicKey{p: G2AffineOne.Mul(k.f.n)}
+	return &PublicKey{p: G2ProjectiveOne.MulFR(k.f)}
 }
 
 // RandKey generates a random secret key.
@@ -159,12 +162,13 @@ func KeyFromBig(i *big.Int) *SecretKey {
 
 // Verify verifies a signature against a message and a public key.
 func Verify(m []byte, pub *PublicKey, sig *Signature, domain uint64) bool {
-	h := HashG1(m, domain)
-	lhs := Pairing(sig.s, G2ProjectiveOne)
-	rhs := Pairing(h, pub.p)
-	return lhs.Equals(rhs)
+	return VerifyPrepared(m, &PreparedPublicKey{pub: pub, q: G2AffineToPrepared(pub.p.ToAffine())}, sig, domain)
 }
 
+// g2PreparedNegOne is the prepared negated G2 generator. Verification checks
+// e(sig, -g2) * e(H(m), pub) == 1 instead of comparing two pairings.
+var g2PreparedNegOne = G2AffineToPrepared(G2AffineOne.Copy().Neg())
+
 // AggregateSignatures adds up all of the signatures.
 func AggregateSignatures(s []*Signature) *Signature {
 	newSig := &Signature{s: G1ProjectiveZero.Copy()}
@@ -234,37 +238,32 @@ func (b sortableByteArray) Swap(i, j int) {
 	b[j], b[i] = b[i], b[j]
 }
 
+// sortByteArrays returns a sorted copy of src.
 func sortByteArrays(src [][]byte) [][]byte {
-	sorted := sortableByteArray(src)
+	sorted := make(sortableByteArray, len(src))
+	copy(sorted, src)
 	sort.Sort(sorted)
 	return sorted
 }
 
-// VerifyAggregate verifies each public key against each message.
-func (s *Signature) VerifyAggregate(pubKeys []*PublicKey, msgs [][]byte, domain uint64) bool {
-	if len(pubKeys) != len(msgs) {
-		return false
-	}
-
-	// messages must be distinct
+// hasDuplicates checks if any message appears more than once.
+func hasDuplicates(msgs [][]byte) bool {
 	msgsSorted := sortByteArrays(msgs)
-	lastMsg := []byte(nil)
-
-	// check for duplicates
-	for _, m := range msgsSorted {
-		if bytes.Equal(m, lastMsg) {
-			return false
+	for i := 1; i < len(msgsSorted); i++ {
+		if bytes.Equal(msgsSorted[i], msgsSorted[i-1]) {
+			return true
 		}
-		lastMsg = m
 	}
+	return false
+}
 
-	lhs := Pairing(s.s, G2ProjectiveOne)
-	rhs := FQ12One.Copy()
-	for i := range pubKeys {
-		h := HashG1(msgs[i], domain)
-		rhs.MulAssign(Pairing(h, pubKeys[i].p))
+// VerifyAggregate verifies each public key against each message.
+func (s *Signature) VerifyAggregate(pubKeys []*PublicKey, msgs [][]byte, domain uint64) bool {
+	prepared := make([]*PreparedPublicKey, len(pubKeys))
+	for i, p := range pubKeys {
+		prepared[i] = &PreparedPublicKey{pub: p, q: G2AffineToPrepared(p.p.ToAffine())}
 	}
-	return lhs.Equals(rhs)
+	return s.VerifyAggregatePrepared(prepared, msgs, domain)
 }
 
 // VerifyAggregateCommon verifies each public key against a message.
@@ -272,10 +271,139 @@ func (s *Signature) VerifyAggregate(pubKeys []*PublicKey, msgs [][]byte, domain
 // provide a proof-of-knowledge of the public key.
 func (s *Signature) VerifyAggregateCommon(pubKeys []*PublicKey, msg []byte, domain uint64) bool {
 	h := HashG1(msg, domain)
-	lhs := Pairing(s.s, G2ProjectiveOne)
-	rhs := FQ12One.Copy()
-	for _, p := range pubKeys {
-		rhs.MulAssign(Pairing(h, p.p))
+	return pairingCheckPrepared(
+		[]*G1Projective{s.s, h},
+		[]*G2Prepared{g2PreparedNegOne, G2AffineToPrepared(AggregatePublicKeys(pubKeys).p.ToAffine())},
+	)
+}
+
+// PreparedPublicKey is a public key with the Miller loop line coefficients
+// precomputed. Verifying many signatures against the same key with a
+// PreparedPublicKey skips the preparation step on every call. It is safe
+// to share between goroutines.
+type PreparedPublicKey struct {
+	pub *PublicKey
+	q   *G2Prepared
+}
+
+// NewPreparedPublicKey precomputes the lines for a public key.
+func NewPreparedPublicKey(p *PublicKey) *PreparedPublicKey {
+	return &PreparedPublicKey{
+		pub: p.Copy(),
+		q:   G2AffineToPrepared(p.p.ToAffine()),
+	}
+}
+
+// PublicKey returns the public key the lines were computed from.
+func (p *PreparedPublicKey) PublicKey() *PublicKey {
+	return p.pub.Copy()
+}
+
+// preparedCoeffCount is the number of line coefficients of a prepared
+// point that is not at infinity.
+var preparedCoeffCount = len(g2PreparedNegOne.coeffs)
+
+// preparedCoeffSize is the size of one serialized line coefficient, three
+// FQ2 elements.
+const preparedCoeffSize = 6 * 48
+
+// Serialize serializes the prepared public key. The output is the 96 byte
+// compressed public key followed by the line coefficients, each FQ
+// element as 48 big-endian bytes.
+func (p *PreparedPublicKey) Serialize() []byte {
+	out := make([]byte, 0, 96+len(p.q.coeffs)*preparedCoeffSize)
+	out = append(out, p.pub.Serialize()...)
+	for _, coeff := range p.q.coeffs {
+		for _, c := range coeff {
+			c0 := c.c0.Bytes()
+			c1 := c.c1.Bytes()
+			out = append(out, c0[:]...)
+			out = append(out, c1[:]...)
+		}
+	}
+	return out
+}
+
+// DeserializePreparedPublicKey deserializes a prepared public key. The input
+// must come from a trusted source, such as storage written by this process
+// with Serialize. Only the public key is validated: the line coefficients
+// are used as given, since checking them would cost as much as preparing
+// the key again, and tampered coefficients can make VerifyPrepared accept
+// signatures that are not valid for the public key. Use NewPreparedPublicKey
+// for keys from untrusted input.
+func DeserializePreparedPublicKey(b []byte) (*PreparedPublicKey, error) {
+	if len(b) < 96 {
+		return nil, errors.New("prepared public key is too short")
+	}
+	pub, err := DeserializePublicKey(b[:96])
+	if err != nil {
+		return nil, err
+	}
+
+	b = b[96:]
+	if pub.p.IsZero() {
+		if len(b) != 0 {
+			return nil, errors.New("unexpected line coefficients for the point at infinity")
+		}
+		return &PreparedPublicKey{pub: pub, q: &G2Prepared{infinity: true}}, nil
+	}
+	if len(b) != preparedCoeffCount*preparedCoeffSize {
+		return nil, errors.New("prepared public key has the wrong length")
+	}
+
+	readFQ := func() (*FQ, error) {
+		var buf [48]byte
+		copy(buf[:], b)
+		b = b[48:]
+		return FQFromBytes(buf)
+	}
+
+	coeffs := make([][3]*FQ2, preparedCoeffCount)
+	for i := range coeffs {
+		for j := range coeffs[i] {
+			c0, err := readFQ()
+			if err != nil {
+				return nil, err
+			}
+			c1, err := readFQ()
+			if err != nil {
+				return nil, err
+			}
+			coeffs[i][j] = NewFQ2(c0, c1)
+		}
+	}
+	return &PreparedPublicKey{pub: pub, q: &G2Prepared{coeffs: coeffs}}, nil
+}
+
+// VerifyPrepared verifies a signature against a message and a prepared
+// public key.
+func VerifyPrepared(m []byte, pub *PreparedPublicKey, sig *Signature, domain uint64) bool {
+	h := HashG1(m, domain)
+	return pairingCheckPrepared(
+		[]*G1Projective{sig.s, h},
+		[]*G2Prepared{g2PreparedNegOne, pub.q},
+	)
+}
+
+// VerifyAggregatePrepared verifies each prepared public key against each
+// message.
+func (s *Signature) VerifyAggregatePrepared(pubKeys []*PreparedPublicKey, msgs [][]byte, domain uint64) bool {
+	if len(pubKeys) != len(msgs) {
+		return false
+	}
+
+	// messages must be distinct
+	if hasDuplicates(msgs) {
+		return false
+	}
+
+	g1s := make([]*G1Projective, len(pubKeys)+1)
+	g2s := make([]*G2Prepared, len(pubKeys)+1)
+	g1s[0] = s.s
+	g2s[0] = g2PreparedNegOne
+	for i := range pubKeys {
+		g1s[i+1] = HashG1(msgs[i], domain)
+		g2s[i+1] = pubKeys[i].q
 	}
-	return lhs.Equals(rhs)
+	return pairingCheckPrepared(g1s, g2s)
 }
@@ -1,8 +1,10 @@
 package bls_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/phoreproject/bls"
@@ -112,6 +114,31 @@ func TestSignVerifyAggregateCommonMissingSig(t *testing.T) {
 	}
 }
 
+// TestVerifyConcurrent verifies signatures against one public key from
+// several goroutines, which all share the prepared G2 generator.
+func TestVerifyConcurrent(t *testing.T) {
+	r := NewXORShift(1)
+	priv, _ := bls.RandKey(r)
+	pub := bls.PrivToPub(priv)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig := bls.Sign(msg, priv, 0)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !bls.Verify(msg, pub, sig, 0) {
+				t.Error("signature did not verify")
+			}
+			if bls.Verify(append(msg, 0), pub, sig, 0) {
+				t.Error("signature verified for the wrong message")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestSignAggregateSigs(t *testing.T) {
 	err := AggregateSignatures(10)
 	if err != nil {
@@ -264,3 +291,131 @@ func TestPubkeySerializeDeserializeBig(t *testing.T) {
 		t.Fatal("message did not verify after serialization/deserialization of uncompressed pubkey")
 	}
 }
+
+func TestSecretKeySerializeDeserialize(t *testing.T) {
+	r := NewXORShift(1)
+	priv, _ := bls.RandKey(r)
+	privSer := priv.Serialize()
+	if len(privSer) != 32 {
+		t.Fatalf("expected 32-byte secret key, got %d bytes", len(privSer))
+	}
+	privDeser := bls.DeserializeSecretKey(privSer)
+	if !bls.PrivToPub(priv).Equals(*bls.PrivToPub(privDeser)) {
+		t.Fatal("secret key changed after serialization/deserialization")
+	}
+}
+
+func TestVerifyAggregateUnsortedMessages(t *testing.T) {
+	r := NewXORShift(2)
+	pubkeys := make([]*bls.PublicKey, 0, 5)
+	msgs := make([][]byte, 0, 5)
+	sigs := make([]*bls.Signature, 0, 5)
+	for i := 5; i > 0; i-- {
+		priv, _ := bls.RandKey(r)
+		msg := []byte(fmt.Sprintf("message %d", i))
+		pubkeys = append(pubkeys, bls.PrivToPub(priv))
+		msgs = append(msgs, msg)
+		sigs = append(sigs, bls.Sign(msg, priv, 0))
+	}
+
+	aggSig := bls.AggregateSignatures(sigs)
+	if !aggSig.VerifyAggregate(pubkeys, msgs, 0) {
+		t.Fatal("aggregate signature over unsorted messages did not verify")
+	}
+	if string(msgs[0]) != "message 5" {
+		t.Fatal("VerifyAggregate reordered the messages")
+	}
+}
+
+func TestPreparedPublicKeyVerify(t *testing.T) {
+	r := NewXORShift(3)
+	pubkeys := make([]*bls.PreparedPublicKey, 0, 5)
+	msgs := make([][]byte, 0, 5)
+	sigs := make([]*bls.Signature, 0, 5)
+	for i := 0; i < 5; i++ {
+		priv, _ := bls.RandKey(r)
+		pub := bls.NewPreparedPublicKey(bls.PrivToPub(priv))
+		msg := []byte(fmt.Sprintf("message %d", i))
+		sig := bls.Sign(msg, priv, 0)
+
+		// verify twice to make sure the prepared key is reusable
+		for j := 0; j < 2; j++ {
+			if !bls.VerifyPrepared(msg, pub, sig, 0) {
+				t.Fatal("signature did not verify against prepared key")
+			}
+		}
+		if bls.VerifyPrepared(msg, pub, sig, 1) {
+			t.Fatal("signature verified with the wrong domain")
+		}
+		if !pub.PublicKey().Equals(*bls.PrivToPub(priv)) {
+			t.Fatal("prepared key returned the wrong public key")
+		}
+
+		pubkeys = append(pubkeys, pub)
+		msgs = append(msgs, msg)
+		sigs = append(sigs, sig)
+	}
+
+	aggSig := bls.AggregateSignatures(sigs)
+	if !aggSig.VerifyAggregatePrepared(pubkeys, msgs, 0) {
+		t.Fatal("aggregate signature did not verify against prepared keys")
+	}
+	if aggSig.VerifyAggregatePrepared(pubkeys[1:], msgs[1:], 0) {
+		t.Fatal("aggregate signature verified with a missing key")
+	}
+}
+
+func TestPreparedPublicKeySerializeDeserialize(t *testing.T) {
+	r := NewXORShift(1)
+	priv, _ := bls.RandKey(r)
+	pub := bls.NewPreparedPublicKey(bls.PrivToPub(priv))
+	msg := []byte(">16 character identical message")
+	sig := bls.Sign(msg, priv, 0)
+
+	pubSer := pub.Serialize()
+	pubDeser, err := bls.DeserializePreparedPublicKey(pubSer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bls.VerifyPrepared(msg, pubDeser, sig, 0) {
+		t.Fatal("message did not verify after serialization/deserialization of prepared pubkey")
+	}
+	if !bytes.Equal(pubDeser.Serialize(), pubSer) {
+		t.Fatal("prepared pubkey did not round-trip")
+	}
+
+	if _, err := bls.DeserializePreparedPublicKey(pubSer[:len(pubSer)-1]); err == nil {
+		t.Fatal("expected truncated prepared pubkey to fail")
+	}
+
+	// an FQ element equal to the modulus is not canonical
+	bad := append([]byte{}, pubSer...)
+	q := bls.QFieldModulus.Bytes()
+	copy(bad[96:144], q)
+	if _, err := bls.DeserializePreparedPublicKey(bad); err == nil {
+		t.Fatal("expected non-canonical coefficient to fail")
+	}
+
+	inf := bls.NewPreparedPublicKey(bls.NewAggregatePubkey())
+	infDeser, err := bls.DeserializePreparedPublicKey(inf.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !infDeser.PublicKey().Equals(*bls.NewAggregatePubkey()) {
+		t.Fatal("prepared pubkey at infinity did not round-trip")
+	}
+}
+
+func BenchmarkBLSVerifyPrepared(b *testing.B) {
+	r := NewXORShift(1)
+	priv, _ := bls.RandKey(r)
+	pub := bls.NewPreparedPublicKey(bls.PrivToPub(priv))
+	msg := []byte(">16 character identical message")
+	sig := bls.Sign(msg, priv, 0)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		bls.VerifyPrepared(msg, pub, sig, 0)
+	}
+}
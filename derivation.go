@@ -0,0 +1,123 @@
+package bls
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// This file implements hierarchical key derivation from EIP-2333 and the
+// derivation paths of EIP-2334. Every derivation step is hardened: a
+// child key can only be derived from the parent secret key.
+
+// Path components defined by EIP-2334.
+const (
+	// DerivationPurpose is the purpose of BLS12-381 derivation paths.
+	DerivationPurpose = 12381
+
+	// DerivationCoinTypeEth is the coin type of Ethereum validator keys.
+	DerivationCoinTypeEth = 3600
+)
+
+// lamportChunks is the number of 32 byte chunks of a Lamport secret key.
+const lamportChunks = 255
+
+// DeriveMasterSK derives the master secret key of a key tree from a seed
+// of at least 32 bytes, such as a BIP-39 seed (EIP-2333).
+func DeriveMasterSK(seed []byte) (*SecretKey, error) {
+	if len(seed) < 32 {
+		return nil, errors.New("seed must be at least 32 bytes")
+	}
+	return hkdfModR(seed, nil, keyGenSalt)
+}
+
+// DeriveChildSK derives the child secret key with the given index from a
+// parent secret key (EIP-2333).
+func DeriveChildSK(parent *SecretKey, index uint32) *SecretKey {
+	sk, err := hkdfModR(parentSKToLamportPK(parent, index), nil, keyGenSalt)
+	if err != nil {
+		// HKDF only fails if more output is requested than it can produce
+		panic(err)
+	}
+	return sk
+}
+
+// parentSKToLamportPK derives the compressed Lamport public key from
+// which a child key is derived.
+func parentSKToLamportPK(parent *SecretKey, index uint32) []byte {
+	salt := []byte{byte(index >> 24), byte(index >> 16), byte(index >> 8), byte(index)}
+	ikm := parent.f.Bytes()
+	notIKM := ikm
+	for i := range notIKM {
+		notIKM[i] ^= 0xff
+	}
+
+	lamportPK := sha256.New()
+	for _, k := range [][]byte{ikm[:], notIKM[:]} {
+		okm := make([]byte, lamportChunks*32)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, k, salt, nil), okm); err != nil {
+			panic(err)
+		}
+		for i := 0; i < lamportChunks; i++ {
+			h := sha256.Sum256(okm[i*32 : (i+1)*32])
+			lamportPK.Write(h[:])
+		}
+	}
+	return lamportPK.Sum(nil)
+}
+
+// ParseDerivationPath parses an EIP-2334 path such as m/12381/3600/0/0/0
+// into its indices. The path must start with m/12381/3600, the purpose and
+// coin type of Ethereum validator keys, and every index must fit in 32 bits.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, errors.New("derivation path must start with m")
+	}
+	if len(parts) < 3 || parts[1] != strconv.Itoa(DerivationPurpose) || parts[2] != strconv.Itoa(DerivationCoinTypeEth) {
+		return nil, errors.New("derivation path must start with m/12381/3600")
+	}
+	indices := make([]uint32, len(parts)-1)
+	for i, p := range parts[1:] {
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid index in derivation path: " + strconv.Quote(p))
+		}
+		indices[i] = uint32(n)
+	}
+	return indices, nil
+}
+
+// DeriveSKFromPath derives the secret key at an EIP-2334 path from a seed.
+// The path must be accepted by ParseDerivationPath.
+func DeriveSKFromPath(seed []byte, path string) (*SecretKey, error) {
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	sk, err := DeriveMasterSK(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indices {
+		sk = DeriveChildSK(sk, index)
+	}
+	return sk, nil
+}
+
+// WithdrawalKeyPath returns the EIP-2334 path of the withdrawal key of an
+// Ethereum validator.
+func WithdrawalKeyPath(validator uint32) string {
+	return "m/" + strconv.Itoa(DerivationPurpose) + "/" + strconv.Itoa(DerivationCoinTypeEth) + "/" +
+		strconv.FormatUint(uint64(validator), 10) + "/0"
+}
+
+// SigningKeyPath returns the EIP-2334 path of the signing key of an
+// Ethereum validator.
+func SigningKeyPath(validator uint32) string {
+	return WithdrawalKeyPath(validator) + "/0"
+}
@@ -0,0 +1,138 @@
+package bls_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+type deriveVector struct {
+	seed     string
+	masterSK string
+	index    uint32
+	childSK  string
+}
+
+// deriveVectors are the test cases of EIP-2333.
+var deriveVectors = []deriveVector{
+	{
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		"6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		0,
+		"20397789859736650942317412262472558107875392172444076792671091975210932703118",
+	},
+	{
+		"3141592653589793238462643383279502884197169399375105820974944592",
+		"29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		3141592653,
+		"25457201688850691947727629385191704516744796114925897962676248250929345014287",
+	},
+	{
+		"0099FF991111002299DD7744EE3355BBDD8844115566CC55663355668888CC00",
+		"27580842291869792442942448775674722299803720648445448686099262467207037398656",
+		4294967295,
+		"29358610794459428860402234341874281240803786294062035874021252734817515685787",
+	},
+	{
+		"d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+		"19022158461524446591288038168518313374041767046816487870552872741050760015818",
+		42,
+		"31372231650479070279774297061823572166496564838472787488249775572789064611981",
+	},
+}
+
+func keyFromDecimal(s string) *bls.SecretKey {
+	n, _ := new(big.Int).SetString(s, 10)
+	return bls.KeyFromBig(n)
+}
+
+func TestDeriveSK(t *testing.T) {
+	for _, v := range deriveVectors {
+		master, err := bls.DeriveMasterSK(mustDecodeHex(v.seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := keyFromDecimal(v.masterSK)
+		if master.String() != expected.String() {
+			t.Fatalf("master key of %s is %s, expected %s", v.seed, master, expected)
+		}
+		if sk, _ := bls.KeyGen(mustDecodeHex(v.seed), nil); sk.String() != master.String() {
+			t.Fatalf("KeyGen of %s does not match the master key", v.seed)
+		}
+
+		child := bls.DeriveChildSK(master, v.index)
+		expected = keyFromDecimal(v.childSK)
+		if child.String() != expected.String() {
+			t.Fatalf("child key %d of %s is %s, expected %s", v.index, v.seed, child, expected)
+		}
+	}
+
+	if _, err := bls.DeriveMasterSK(make([]byte, 31)); err == nil {
+		t.Fatal("expected an error for a short seed")
+	}
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	indices, err := bls.ParseDerivationPath("m/12381/3600/4294967295/0/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []uint32{12381, 3600, 4294967295, 0, 0}
+	if len(indices) != len(expected) {
+		t.Fatalf("parsed %v, expected %v", indices, expected)
+	}
+	for i := range expected {
+		if indices[i] != expected[i] {
+			t.Fatalf("parsed %v, expected %v", indices, expected)
+		}
+	}
+
+	if indices, err := bls.ParseDerivationPath("m/12381/3600"); err != nil || len(indices) != 2 {
+		t.Fatal("failed to parse the coin type path")
+	}
+
+	invalid := []string{
+		"", "/12381", "n/12381", "m/", "m//0", "m/12381/", "m/4294967296", "m/-1", "m/1'", "m/0x10",
+		"m", "m/0", "m/12381", "m/12381/60/0/0", "m/44/3600/0/0", "m/012381/3600", "m/12381/3600/", "m/12381/3600/-1",
+	}
+	for _, p := range invalid {
+		if _, err := bls.ParseDerivationPath(p); err == nil {
+			t.Errorf("parsed invalid path %q", p)
+		}
+	}
+}
+
+func TestDeriveSKFromPath(t *testing.T) {
+	seed := mustDecodeHex(deriveVectors[0].seed)
+	sk, err := bls.DeriveSKFromPath(seed, "m/12381/3600/5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := bls.DeriveMasterSK(seed)
+	for _, index := range []uint32{12381, 3600, 5} {
+		expected = bls.DeriveChildSK(expected, index)
+	}
+	if sk.String() != expected.String() {
+		t.Fatalf("key at m/12381/3600/5 is %s, expected %s", sk, expected)
+	}
+	if _, err := bls.DeriveSKFromPath(seed, "m/0"); err == nil {
+		t.Fatal("expected an error for a path outside m/12381/3600")
+	}
+
+	if bls.SigningKeyPath(7) != "m/12381/3600/7/0/0" || bls.WithdrawalKeyPath(7) != "m/12381/3600/7/0" {
+		t.Fatal("unexpected validator key paths")
+	}
+	signing, _ := bls.DeriveSKFromPath(seed, bls.SigningKeyPath(7))
+	withdrawal, _ := bls.DeriveSKFromPath(seed, bls.WithdrawalKeyPath(7))
+	if bls.DeriveChildSK(withdrawal, 0).String() != signing.String() {
+		t.Fatal("signing key is not the first child of the withdrawal key")
+	}
+}
+
+func BenchmarkDeriveChildSK(b *testing.B) {
+	sk, _ := bls.RandKey(NewXORShift(1))
+	for i := 0; i < b.N; i++ {
+		bls.DeriveChildSK(sk, uint32(i))
+	}
+}
@@ -0,0 +1,504 @@
+package bls
+
+import (
+	"errors"
+	"io"
+)
+
+// This file implements distributed key generation, so that a threshold
+// group key is created without any single party ever knowing the secret
+// key. It is Pedersen's joint Feldman protocol with the complaint round of
+// Gennaro, Jarecki, Krawczyk and Rabin: every participant deals a random
+// secret with Feldman VSS, and the group secret key is the sum of the
+// secrets of the dealers that were not disqualified.
+//
+// The protocol runs in phases, and every participant must finish a phase
+// before any participant starts the next one:
+//
+//	Deal      broadcast a commitment and send each participant its share
+//	Verify    check the received shares against the commitments
+//	Complain  broadcast a complaint about every dealer with a bad share
+//	Justify   reveal the share of every participant that complained
+//	Finalize  disqualify dealers and combine the shares of the others
+//
+// A dealer is disqualified if it does not publish a commitment, if t or
+// more participants complain about it, or if it fails to justify a share.
+// Disqualification only depends on broadcast messages, so all honest
+// participants agree on the qualified dealers.
+
+// DKGMessageType is the type of a DKG message.
+type DKGMessageType int
+
+const (
+	// DKGCommitment is the commitment a dealer broadcasts.
+	DKGCommitment DKGMessageType = iota
+
+	// DKGShare is the share a dealer sends privately to a participant.
+	DKGShare
+
+	// DKGComplaint is a broadcast complaint about the share of a dealer.
+	DKGComplaint
+
+	// DKGJustification is the share of a participant that complained,
+	// broadcast by the dealer it complained about.
+	DKGJustification
+)
+
+// DKGMessage is a message of the DKG protocol. Only the fields of its type
+// are set.
+type DKGMessage struct {
+	Type DKGMessageType
+	From uint32
+
+	// Commitment is set for DKGCommitment messages.
+	Commitment *VSSCommitment
+
+	// Share is set for DKGShare and DKGJustification messages.
+	Share *SecretKeyShare
+
+	// Accused is the dealer of a DKGComplaint message.
+	Accused uint32
+}
+
+// DKGTransport delivers the messages of one participant. It must
+// authenticate the sender of every message and set its From field, keep
+// messages sent with Send confidential, and deliver every broadcast to all
+// participants.
+type DKGTransport interface {
+	// Broadcast sends a message to every other participant.
+	Broadcast(msg *DKGMessage) error
+
+	// Send sends a message to one participant.
+	Send(to uint32, msg *DKGMessage) error
+
+	// Receive returns the messages received since the last call.
+	Receive() ([]*DKGMessage, error)
+}
+
+// DKGResult is the outcome of the DKG for one participant.
+type DKGResult struct {
+	// Share is the secret key share of the participant.
+	Share *SecretKeyShare
+
+	// PublicKey is the group public key.
+	PublicKey *PublicKey
+
+	// Commitment is the sum of the commitments of the qualified dealers.
+	// It gives the public key share of every participant.
+	Commitment *VSSCommitment
+
+	// Qualified lists the addresses of the dealers that were not
+	// disqualified. In a DKG, addresses are the participants' indices.
+	Qualified []uint32
+}
+
+type dkgPhase int
+
+const (
+	dkgStart dkgPhase = iota
+	dkgDealt
+	dkgVerified
+	dkgComplained
+	dkgJustified
+	dkgFinalized
+)
+
+// dkgMode selects what the dealers share and how the deals are combined.
+type dkgMode int
+
+const (
+	// dkgFresh deals random secrets and adds up the deals.
+	dkgFresh dkgMode = iota
+
+	// dkgRefresh deals zero and adds the deals to the old shares.
+	dkgRefresh
+
+	// dkgReshare deals the old shares and interpolates the deals.
+	dkgReshare
+)
+
+// DKGParticipant runs the DKG protocol, or one of the resharing protocols
+// built on it, for one participant.
+type DKGParticipant struct {
+	address   uint32
+	dealers   []uint32
+	receivers []uint32
+	t         int
+	transport DKGTransport
+	rand      io.Reader
+	phase     dkgPhase
+
+	mode          dkgMode
+	oldShare      *SecretKeyShare
+	oldCommitment *VSSCommitment
+
+	poly           polynomial
+	commitments    map[uint32]*VSSCommitment
+	shares         map[uint32]*SecretKeyShare
+	accused        []uint32
+	complaints     map[uint32]map[uint32]bool
+	justifications map[uint32]map[uint32]*SecretKeyShare
+	disqualified   map[uint32]bool
+}
+
+// NewDKGParticipant creates the participant with the given index, from 1
+// to n, of a t-of-n DKG. Participants are addressed by their index on the
+// transport. Secrets are read from r.
+func NewDKGParticipant(index uint32, t int, n int, transport DKGTransport, r io.Reader) (*DKGParticipant, error) {
+	if err := checkThreshold(t, n); err != nil {
+		return nil, err
+	}
+	if index < 1 || uint64(index) > uint64(n) {
+		return nil, errors.New("participant index must be between 1 and n")
+	}
+	members := make([]uint32, n)
+	for i := range members {
+		members[i] = uint32(i + 1)
+	}
+	return newDKGParticipant(index, members, members, t, transport, r), nil
+}
+
+// newDKGParticipant creates a participant with the given address. The
+// share index of a dealer or receiver is its position in the list plus
+// one.
+func newDKGParticipant(address uint32, dealers []uint32, receivers []uint32, t int, transport DKGTransport, r io.Reader) *DKGParticipant {
+	return &DKGParticipant{
+		address:        address,
+		dealers:        dealers,
+		receivers:      receivers,
+		t:              t,
+		transport:      transport,
+		rand:           r,
+		commitments:    make(map[uint32]*VSSCommitment),
+		shares:         make(map[uint32]*SecretKeyShare),
+		complaints:     make(map[uint32]map[uint32]bool),
+		justifications: make(map[uint32]map[uint32]*SecretKeyShare),
+		disqualified:   make(map[uint32]bool),
+	}
+}
+
+// advance moves to the next phase.
+func (p *DKGParticipant) advance(phase dkgPhase) error {
+	if p.phase != phase-1 {
+		return errors.New("DKG phase called out of order")
+	}
+	p.phase = phase
+	return nil
+}
+
+// memberIndex returns the share index of an address in a committee, or
+// zero if it is not a member.
+func memberIndex(members []uint32, address uint32) uint32 {
+	for i, m := range members {
+		if m == address {
+			return uint32(i + 1)
+		}
+	}
+	return 0
+}
+
+// receive stores the messages received since the last call. Messages of
+// later phases can arrive early from participants that are ahead, so they
+// are kept until their phase. Only the first message of a kind from each
+// sender counts.
+func (p *DKGParticipant) receive() error {
+	msgs, err := p.transport.Receive()
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		if msg.From == p.address {
+			continue
+		}
+		isDealer := memberIndex(p.dealers, msg.From) != 0
+		switch msg.Type {
+		case DKGCommitment:
+			if _, found := p.commitments[msg.From]; isDealer && !found && msg.Commitment != nil {
+				p.commitments[msg.From] = msg.Commitment
+			}
+		case DKGShare:
+			if _, found := p.shares[msg.From]; isDealer && !found && msg.Share != nil && msg.Share.Key != nil {
+				p.shares[msg.From] = msg.Share
+			}
+		case DKGComplaint:
+			if memberIndex(p.receivers, msg.From) != 0 && memberIndex(p.dealers, msg.Accused) != 0 && msg.Accused != msg.From {
+				p.addComplaint(msg.Accused, msg.From)
+			}
+		case DKGJustification:
+			if !isDealer || msg.Share == nil || msg.Share.Key == nil {
+				continue
+			}
+			if p.justifications[msg.From] == nil {
+				p.justifications[msg.From] = make(map[uint32]*SecretKeyShare)
+			}
+			if _, found := p.justifications[msg.From][msg.Share.Index]; !found {
+				p.justifications[msg.From][msg.Share.Index] = msg.Share
+			}
+		}
+	}
+	return nil
+}
+
+// Deal broadcasts the commitment to a new polynomial and sends every
+// other receiver its share. In a DKG the polynomial shares a random
+// secret. Participants that are not dealers send nothing.
+func (p *DKGParticipant) Deal() error {
+	if err := p.advance(dkgDealt); err != nil {
+		return err
+	}
+
+	var secret *FR
+	switch p.mode {
+	case dkgFresh:
+		var err error
+		secret, err = RandFR(p.rand)
+		if err != nil {
+			return err
+		}
+	case dkgRefresh:
+		secret = FRZero.Copy()
+	case dkgReshare:
+		if p.oldShare == nil {
+			return nil
+		}
+		secret = p.oldShare.Key.f
+	}
+	var err error
+	p.poly, err = randPolynomial(secret, p.t-1, p.rand)
+	if err != nil {
+		return err
+	}
+
+	commitment := p.poly.commit()
+	p.commitments[p.address] = commitment
+	if err := p.transport.Broadcast(&DKGMessage{Type: DKGCommitment, From: p.address, Commitment: commitment}); err != nil {
+		return err
+	}
+	for i, share := range p.poly.shares(len(p.receivers)) {
+		if p.receivers[i] == p.address {
+			p.shares[p.address] = share
+			continue
+		}
+		if err := p.transport.Send(p.receivers[i], &DKGMessage{Type: DKGShare, From: p.address, Share: share}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSecret checks the commitment to the secret of a dealer. Resharing
+// dealers must deal zero or their old share.
+func (p *DKGParticipant) checkSecret(dealer uint32, commitment *VSSCommitment) bool {
+	switch p.mode {
+	case dkgRefresh:
+		return commitment.coeffs[0].IsZero()
+	case dkgReshare:
+		old := p.oldCommitment.PublicKeyShare(memberIndex(p.dealers, dealer))
+		return commitment.coeffs[0].Equal(old.PublicKey.p)
+	}
+	return true
+}
+
+// Verify receives the commitments and shares of the other dealers and
+// checks every share against its dealer's commitment. Dealers without a
+// valid commitment are disqualified, and dealers with a missing or
+// invalid share are complained about in the next phase.
+func (p *DKGParticipant) Verify() error {
+	if err := p.advance(dkgVerified); err != nil {
+		return err
+	}
+	if err := p.receive(); err != nil {
+		return err
+	}
+
+	index := memberIndex(p.receivers, p.address)
+	for _, dealer := range p.dealers {
+		if dealer == p.address {
+			continue
+		}
+		commitment, found := p.commitments[dealer]
+		if !found || commitment.Threshold() != p.t || !p.checkSecret(dealer, commitment) {
+			p.disqualified[dealer] = true
+			continue
+		}
+		if index == 0 {
+			continue
+		}
+		share, found := p.shares[dealer]
+		if !found || share.Index != index || !commitment.VerifyShare(share) {
+			delete(p.shares, dealer)
+			p.accused = append(p.accused, dealer)
+		}
+	}
+	return nil
+}
+
+// addComplaint records a complaint about a dealer.
+func (p *DKGParticipant) addComplaint(dealer uint32, from uint32) {
+	if p.complaints[dealer] == nil {
+		p.complaints[dealer] = make(map[uint32]bool)
+	}
+	p.complaints[dealer][from] = true
+}
+
+// Complain broadcasts a complaint about every dealer whose share did not
+// verify.
+func (p *DKGParticipant) Complain() error {
+	if err := p.advance(dkgComplained); err != nil {
+		return err
+	}
+	for _, dealer := range p.accused {
+		p.addComplaint(dealer, p.address)
+		if err := p.transport.Broadcast(&DKGMessage{Type: DKGComplaint, From: p.address, Accused: dealer}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Justify receives the complaints and answers every complaint about this
+// participant's deal by broadcasting the share of the receiver that
+// complained.
+func (p *DKGParticipant) Justify() error {
+	if err := p.advance(dkgJustified); err != nil {
+		return err
+	}
+	if err := p.receive(); err != nil {
+		return err
+	}
+	if p.poly == nil {
+		return nil
+	}
+
+	for i, complainer := range p.receivers {
+		if !p.complaints[p.address][complainer] {
+			continue
+		}
+		index := uint32(i + 1)
+		share := &SecretKeyShare{
+			Index: index,
+			Key:   &SecretKey{f: p.poly.evaluate(frFromIndex(index))},
+		}
+		if err := p.transport.Broadcast(&DKGMessage{Type: DKGJustification, From: p.address, Share: share}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Finalize receives the justifications, disqualifies the dealers that
+// misbehaved and combines the deals of the others. Participants that do
+// not receive a share get a result without one.
+func (p *DKGParticipant) Finalize() (*DKGResult, error) {
+	if err := p.advance(dkgFinalized); err != nil {
+		return nil, err
+	}
+	if err := p.receive(); err != nil {
+		return nil, err
+	}
+
+	for _, dealer := range p.dealers {
+		complainers := p.complaints[dealer]
+		if p.disqualified[dealer] || len(complainers) == 0 {
+			continue
+		}
+		// t revealed shares would reveal the dealer's secret
+		if len(complainers) >= p.t {
+			p.disqualified[dealer] = true
+			continue
+		}
+		if dealer == p.address {
+			continue
+		}
+		for complainer := range complainers {
+			share, found := p.justifications[dealer][memberIndex(p.receivers, complainer)]
+			if !found || !p.commitments[dealer].VerifyShare(share) {
+				p.disqualified[dealer] = true
+				break
+			}
+			if complainer == p.address {
+				p.shares[dealer] = share
+			}
+		}
+	}
+
+	var qualified []uint32
+	for _, dealer := range p.dealers {
+		if !p.disqualified[dealer] {
+			qualified = append(qualified, dealer)
+		}
+	}
+	if len(qualified) == 0 {
+		return nil, errors.New("every dealer was disqualified")
+	}
+	return p.combine(qualified)
+}
+
+// combine adds up the deals of the qualified dealers, weighted with
+// Lagrange coefficients when resharing.
+func (p *DKGParticipant) combine(qualified []uint32) (*DKGResult, error) {
+	used := qualified
+	var weights []*FR
+	key := FRZero.Copy()
+	coeffs := make([]*G2Projective, p.t)
+	for i := range coeffs {
+		coeffs[i] = G2ProjectiveZero.Copy()
+	}
+
+	switch p.mode {
+	case dkgRefresh:
+		if p.oldShare != nil {
+			key = p.oldShare.Key.f.Copy()
+		}
+		for i, c := range p.oldCommitment.coeffs {
+			coeffs[i] = c.Copy()
+		}
+	case dkgReshare:
+		// any t old shares determine the secret, so the first t
+		// qualified dealers are enough
+		oldT := p.oldCommitment.Threshold()
+		if len(qualified) < oldT {
+			return nil, errors.New("not enough qualified dealers to reshare the key")
+		}
+		used = qualified[:oldT]
+		indices := make([]uint32, len(used))
+		for i, dealer := range used {
+			indices[i] = memberIndex(p.dealers, dealer)
+		}
+		var err error
+		weights, err = lagrangeCoefficients(indices)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	index := memberIndex(p.receivers, p.address)
+	for i, dealer := range used {
+		commitment := p.commitments[dealer]
+		if weights != nil {
+			commitment = commitment.scale(weights[i])
+		}
+		for j, c := range commitment.coeffs {
+			coeffs[j] = coeffs[j].Add(c)
+		}
+		if index == 0 {
+			continue
+		}
+		share := p.shares[dealer].Key.f
+		if weights != nil {
+			share = share.Mul(weights[i])
+		}
+		key.AddAssign(share)
+	}
+
+	commitment := &VSSCommitment{coeffs: coeffs}
+	result := &DKGResult{
+		PublicKey:  commitment.PublicKey(),
+		Commitment: commitment,
+		Qualified:  qualified,
+	}
+	if index != 0 {
+		result.Share = &SecretKeyShare{Index: index, Key: &SecretKey{f: key}}
+	}
+	return result, nil
+}
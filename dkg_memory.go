@@ -0,0 +1,70 @@
+package bls
+
+import (
+	"errors"
+	"sync"
+)
+
+// MemoryNetwork connects DKG participants in the same process. It is
+// meant for tests and simulations.
+type MemoryNetwork struct {
+	lock    sync.Mutex
+	inboxes map[uint32][]*DKGMessage
+}
+
+// NewMemoryNetwork creates an empty network.
+func NewMemoryNetwork() *MemoryNetwork {
+	return &MemoryNetwork{inboxes: make(map[uint32][]*DKGMessage)}
+}
+
+// Transport connects the participant with the given index to the network.
+// All participants must be connected before the first message is sent.
+func (n *MemoryNetwork) Transport(index uint32) DKGTransport {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if _, found := n.inboxes[index]; !found {
+		n.inboxes[index] = nil
+	}
+	return &memoryTransport{network: n, index: index}
+}
+
+// deliver adds a copy of a message from a participant to an inbox.
+func (n *MemoryNetwork) deliver(from uint32, to uint32, msg *DKGMessage) {
+	delivered := *msg
+	delivered.From = from
+	n.inboxes[to] = append(n.inboxes[to], &delivered)
+}
+
+type memoryTransport struct {
+	network *MemoryNetwork
+	index   uint32
+}
+
+func (t *memoryTransport) Broadcast(msg *DKGMessage) error {
+	t.network.lock.Lock()
+	defer t.network.lock.Unlock()
+	for to := range t.network.inboxes {
+		if to != t.index {
+			t.network.deliver(t.index, to, msg)
+		}
+	}
+	return nil
+}
+
+func (t *memoryTransport) Send(to uint32, msg *DKGMessage) error {
+	t.network.lock.Lock()
+	defer t.network.lock.Unlock()
+	if _, found := t.network.inboxes[to]; !found {
+		return errors.New("participant is not connected to the network")
+	}
+	t.network.deliver(t.index, to, msg)
+	return nil
+}
+
+func (t *memoryTransport) Receive() ([]*DKGMessage, error) {
+	t.network.lock.Lock()
+	defer t.network.lock.Unlock()
+	msgs := t.network.inboxes[t.index]
+	t.network.inboxes[t.index] = nil
+	return msgs, nil
+}
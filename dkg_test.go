@@ -0,0 +1,215 @@
+package bls_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+// corruptTransport replaces the shares a dealer sends to some
+// participants and can drop its justifications. With nilKeys, the shares
+// and justifications have no key.
+type corruptTransport struct {
+	bls.DKGTransport
+	corruptTo          map[uint32]bool
+	dropJustifications bool
+	nilKeys            bool
+	r                  *XORShift
+}
+
+func (c *corruptTransport) Send(to uint32, msg *bls.DKGMessage) error {
+	if msg.Type == bls.DKGShare && c.corruptTo[to] {
+		var key *bls.SecretKey
+		if !c.nilKeys {
+			key, _ = bls.RandKey(c.r)
+		}
+		msg = &bls.DKGMessage{Type: bls.DKGShare, Share: &bls.SecretKeyShare{Index: to, Key: key}}
+	}
+	return c.DKGTransport.Send(to, msg)
+}
+
+func (c *corruptTransport) Broadcast(msg *bls.DKGMessage) error {
+	if msg.Type == bls.DKGJustification && c.dropJustifications {
+		return nil
+	}
+	if msg.Type == bls.DKGJustification && c.nilKeys {
+		msg = &bls.DKGMessage{Type: bls.DKGJustification, Share: &bls.SecretKeyShare{Index: msg.Share.Index}}
+	}
+	return c.DKGTransport.Broadcast(msg)
+}
+
+// runDKG runs the DKG between the given participants of a t-of-n group.
+// wrap can replace the transport of a participant.
+func runDKG(t *testing.T, threshold int, n int, indices []uint32, wrap func(index uint32, transport bls.DKGTransport) bls.DKGTransport) []*bls.DKGResult {
+	r := NewXORShift(1)
+	network := bls.NewMemoryNetwork()
+	transports := make([]bls.DKGTransport, n+1)
+	for index := 1; index <= n; index++ {
+		transports[index] = network.Transport(uint32(index))
+	}
+	participants := make([]*bls.DKGParticipant, len(indices))
+	for i, index := range indices {
+		transport := transports[index]
+		if wrap != nil {
+			transport = wrap(index, transport)
+		}
+		p, err := bls.NewDKGParticipant(index, threshold, n, transport, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		participants[i] = p
+	}
+	return runPhases(t, participants)
+}
+
+// runPhases runs every phase of the protocol for all participants, one
+// phase at a time.
+func runPhases(t *testing.T, participants []*bls.DKGParticipant) []*bls.DKGResult {
+	phases := []func(p *bls.DKGParticipant) error{
+		(*bls.DKGParticipant).Deal,
+		(*bls.DKGParticipant).Verify,
+		(*bls.DKGParticipant).Complain,
+		(*bls.DKGParticipant).Justify,
+	}
+	for _, phase := range phases {
+		for _, p := range participants {
+			if err := phase(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	results := make([]*bls.DKGResult, len(participants))
+	for i, p := range participants {
+		result, err := p.Finalize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// checkDKGResults checks that the participants agree on the group key and
+// that any t of them can sign for it.
+func checkDKGResults(t *testing.T, threshold int, results []*bls.DKGResult, qualified []uint32) {
+	msg := []byte("DKG message")
+	pub := results[0].PublicKey
+	sigs := make([]*bls.SignatureShare, len(results))
+	for i, result := range results {
+		if !result.PublicKey.Equals(*pub) {
+			t.Fatalf("participant %d has a different group public key", result.Share.Index)
+		}
+		if !reflect.DeepEqual(result.Qualified, qualified) {
+			t.Fatalf("participant %d qualified %v, expected %v", result.Share.Index, result.Qualified, qualified)
+		}
+		if !result.Commitment.VerifyShare(result.Share) {
+			t.Fatalf("share of participant %d does not match the group commitment", result.Share.Index)
+		}
+		if !bytes.Equal(result.Commitment.Serialize(), results[0].Commitment.Serialize()) {
+			t.Fatalf("participant %d has a different group commitment", result.Share.Index)
+		}
+		sigs[i] = result.Share.Sign(msg, 0)
+		if !bls.VerifySignatureShare(msg, results[0].Commitment.PublicKeyShare(result.Share.Index), sigs[i], 0) {
+			t.Fatalf("signature share of participant %d did not verify", result.Share.Index)
+		}
+	}
+
+	for start := 0; start+threshold <= len(sigs); start++ {
+		sig, err := bls.RecoverSignature(sigs[start:], threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bls.Verify(msg, pub, sig, 0) {
+			t.Fatalf("signature recovered from shares %d to %d did not verify", start, start+threshold-1)
+		}
+	}
+}
+
+func TestDKG(t *testing.T) {
+	results := runDKG(t, 3, 5, []uint32{1, 2, 3, 4, 5}, nil)
+	checkDKGResults(t, 3, results, []uint32{1, 2, 3, 4, 5})
+}
+
+func TestDKGJustifiedComplaint(t *testing.T) {
+	// dealer 2 sends a bad share to participant 4 but reveals the right one
+	results := runDKG(t, 3, 5, []uint32{1, 2, 3, 4, 5}, func(index uint32, transport bls.DKGTransport) bls.DKGTransport {
+		if index != 2 {
+			return transport
+		}
+		return &corruptTransport{DKGTransport: transport, corruptTo: map[uint32]bool{4: true}, r: NewXORShift(2)}
+	})
+	checkDKGResults(t, 3, results, []uint32{1, 2, 3, 4, 5})
+}
+
+func TestDKGDisqualifyUnjustified(t *testing.T) {
+	// dealer 2 sends a bad share to participant 4 and never justifies it
+	results := runDKG(t, 3, 5, []uint32{1, 2, 3, 4, 5}, func(index uint32, transport bls.DKGTransport) bls.DKGTransport {
+		if index != 2 {
+			return transport
+		}
+		return &corruptTransport{DKGTransport: transport, corruptTo: map[uint32]bool{4: true}, dropJustifications: true, r: NewXORShift(3)}
+	})
+	// the dishonest dealer does not learn that it was disqualified
+	honest := append(results[:1], results[2:]...)
+	checkDKGResults(t, 3, honest, []uint32{1, 3, 4, 5})
+}
+
+func TestDKGNilKeyShare(t *testing.T) {
+	// dealer 2 sends participant 4 a share without a key and its
+	// justification is also replaced by a share without a key, so it is
+	// disqualified
+	results := runDKG(t, 3, 5, []uint32{1, 2, 3, 4, 5}, func(index uint32, transport bls.DKGTransport) bls.DKGTransport {
+		if index != 2 {
+			return transport
+		}
+		return &corruptTransport{DKGTransport: transport, corruptTo: map[uint32]bool{4: true}, nilKeys: true}
+	})
+	honest := append(results[:1], results[2:]...)
+	checkDKGResults(t, 3, honest, []uint32{1, 3, 4, 5})
+}
+
+func TestDKGDisqualifyTooManyComplaints(t *testing.T) {
+	// justifying three shares of a 3-of-5 deal would reveal the secret
+	results := runDKG(t, 3, 5, []uint32{1, 2, 3, 4, 5}, func(index uint32, transport bls.DKGTransport) bls.DKGTransport {
+		if index != 5 {
+			return transport
+		}
+		return &corruptTransport{DKGTransport: transport, corruptTo: map[uint32]bool{1: true, 2: true, 3: true}, r: NewXORShift(4)}
+	})
+	checkDKGResults(t, 3, results, []uint32{1, 2, 3, 4})
+}
+
+func TestDKGMissingDealer(t *testing.T) {
+	// participant 3 never takes part, so it never deals
+	results := runDKG(t, 2, 4, []uint32{1, 2, 4}, nil)
+	checkDKGResults(t, 2, results, []uint32{1, 2, 4})
+}
+
+func TestDKGParameters(t *testing.T) {
+	network := bls.NewMemoryNetwork()
+	r := NewXORShift(5)
+	network.Transport(2)
+	network.Transport(3)
+	if _, err := bls.NewDKGParticipant(0, 2, 3, network.Transport(0), r); err == nil {
+		t.Fatal("participant with index zero was accepted")
+	}
+	if _, err := bls.NewDKGParticipant(4, 2, 3, network.Transport(4), r); err == nil {
+		t.Fatal("participant with an index above n was accepted")
+	}
+	if _, err := bls.NewDKGParticipant(1, 4, 3, network.Transport(1), r); err == nil {
+		t.Fatal("threshold above n was accepted")
+	}
+
+	p, _ := bls.NewDKGParticipant(1, 2, 3, network.Transport(1), r)
+	if err := p.Verify(); err == nil {
+		t.Fatal("Verify was allowed before Deal")
+	}
+	if err := p.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Deal(); err == nil {
+		t.Fatal("Deal was allowed twice")
+	}
+}
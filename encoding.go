@@ -0,0 +1,149 @@
+package bls
+
+import "errors"
+
+// This file implements the compressed point encoding used by the IETF
+// signature schemes and most other BLS12-381 libraries (the "ZCash"
+// format). The three most significant bits of the first byte are flags:
+// compression, point at infinity and the sign of y. G2 x coordinates are
+// written as c1 followed by c0, which differs from CompressG2.
+
+// Sizes of compressed points in the standard encoding.
+const (
+	// G1CompressedSize is the size of a compressed G1 point.
+	G1CompressedSize = 48
+
+	// G2CompressedSize is the size of a compressed G2 point.
+	G2CompressedSize = 96
+)
+
+const (
+	encodingCompressedFlag = 1 << 7
+	encodingInfinityFlag   = 1 << 6
+	encodingSignFlag       = 1 << 5
+	encodingFlagMask       = encodingCompressedFlag | encodingInfinityFlag | encodingSignFlag
+)
+
+// encodeG1 encodes a G1 point in the standard compressed form.
+func encodeG1(p *G1Affine) []byte {
+	out := make([]byte, G1CompressedSize)
+	if p.IsZero() {
+		out[0] = encodingCompressedFlag | encodingInfinityFlag
+		return out
+	}
+	x := p.x.Bytes()
+	copy(out, x[:])
+	out[0] |= encodingCompressedFlag
+	if p.y.Cmp(p.y.Neg()) > 0 {
+		out[0] |= encodingSignFlag
+	}
+	return out
+}
+
+// encodeG2 encodes a G2 point in the standard compressed form.
+func encodeG2(p *G2Affine) []byte {
+	out := make([]byte, G2CompressedSize)
+	if p.IsZero() {
+		out[0] = encodingCompressedFlag | encodingInfinityFlag
+		return out
+	}
+	c1 := p.x.c1.Bytes()
+	c0 := p.x.c0.Bytes()
+	copy(out, c1[:])
+	copy(out[48:], c0[:])
+	out[0] |= encodingCompressedFlag
+	if p.y.Cmp(p.y.Neg()) > 0 {
+		out[0] |= encodingSignFlag
+	}
+	return out
+}
+
+// decodeFlags checks the flags of a compressed point and reports whether it
+// is the point at infinity and whether y is the larger root. The flags are
+// cleared from b.
+func decodeFlags(b []byte) (infinity bool, greatest bool, err error) {
+	flags := b[0] & encodingFlagMask
+	b[0] &^= encodingFlagMask
+	if flags&encodingCompressedFlag == 0 {
+		return false, false, errors.New("point is not compressed")
+	}
+	if flags&encodingInfinityFlag == 0 {
+		return false, flags&encodingSignFlag != 0, nil
+	}
+	if flags&encodingSignFlag != 0 {
+		return false, false, errors.New("point at infinity has the sign flag set")
+	}
+	for _, c := range b {
+		if c != 0 {
+			return false, false, errors.New("point at infinity has a nonzero x coordinate")
+		}
+	}
+	return true, false, nil
+}
+
+// decodeFQ reads a canonical field element from 48 bytes.
+func decodeFQ(b []byte) (*FQ, error) {
+	var buf [48]byte
+	copy(buf[:], b)
+	return FQFromBytes(buf)
+}
+
+// decodeG1 decodes a G1 point in the standard compressed form. It rejects
+// encodings that are not canonical and points outside of G1.
+func decodeG1(in []byte) (*G1Affine, error) {
+	if len(in) != G1CompressedSize {
+		return nil, errors.New("compressed G1 point has the wrong length")
+	}
+	b := append([]byte{}, in...)
+	infinity, greatest, err := decodeFlags(b)
+	if err != nil {
+		return nil, err
+	}
+	if infinity {
+		return G1AffineZero.Copy(), nil
+	}
+	x, err := decodeFQ(b)
+	if err != nil {
+		return nil, err
+	}
+	p := GetG1PointFromX(x, greatest)
+	if p == nil {
+		return nil, errors.New("point is not on the curve")
+	}
+	if !p.IsInCorrectSubgroupAssumingOnCurve() {
+		return nil, errors.New("point is not in the correct subgroup")
+	}
+	return p, nil
+}
+
+// decodeG2 decodes a G2 point in the standard compressed form. It rejects
+// encodings that are not canonical and points outside of G2.
+func decodeG2(in []byte) (*G2Affine, error) {
+	if len(in) != G2CompressedSize {
+		return nil, errors.New("compressed G2 point has the wrong length")
+	}
+	b := append([]byte{}, in...)
+	infinity, greatest, err := decodeFlags(b)
+	if err != nil {
+		return nil, err
+	}
+	if infinity {
+		return G2AffineZero.Copy(), nil
+	}
+	c1, err := decodeFQ(b[:48])
+	if err != nil {
+		return nil, err
+	}
+	c0, err := decodeFQ(b[48:])
+	if err != nil {
+		return nil, err
+	}
+	p := GetG2PointFromX(NewFQ2(c0, c1), greatest)
+	if p == nil {
+		return nil, errors.New("point is not on the curve")
+	}
+	if !p.IsInCorrectSubgroupAssumingOnCurve() {
+		return nil, errors.New("point is not in the correct subgroup")
+	}
+	return p, nil
+}
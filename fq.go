@@ -2,15 +2,17 @@ package bls
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"math/big"
 )
 
-// FQ is an element in a field.
+// FQ is an element in a field. It is stored in Montgomery form as
+// six 64-bit limbs.
 type FQ struct {
-	n *big.Int
+	n FQRepr
 }
 
 var bigZero = big.NewInt(0)
@@ -20,167 +22,191 @@ var bigTwo = big.NewInt(2)
 // QFieldModulus is the modulus of the field.
 var QFieldModulus, _ = new(big.Int).SetString("4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559787", 10)
 
-func primeFieldInv(a *big.Int, n *big.Int) *big.Int {
-	if a.Cmp(bigZero) == 0 {
-		return big.NewInt(0)
-	}
-	lm := big.NewInt(1)
-	hm := big.NewInt(0)
-	low := new(big.Int).Mod(a, n)
-	high := new(big.Int).Set(n)
-	for low.Cmp(bigOne) > 0 {
-		r := new(big.Int).Div(high, low)
-		lm.Mul(lm, r)
-		hm.Sub(hm, lm)
-		r.Mul(r, low)
-		high.Sub(high, r)
-		lm, low, hm, high = hm, high, lm, low
-	}
-	lm.Mod(lm, n)
-	return lm
-}
-
 // NewFQ creates a new field element.
 func NewFQ(n *big.Int) *FQ {
 	outN := n
 	if n.Cmp(QFieldModulus) >= 0 || n.Cmp(bigZero) < 0 {
-		outN.Mod(outN, QFieldModulus)
+		outN = new(big.Int).Mod(n, QFieldModulus)
+	}
+	r, _ := FQReprFromBig(outN)
+	return FQReprToFQ(r)
+}
+
+// FQReprToFQ converts a number less than the modulus into a field
+// element.
+func FQReprToFQ(r FQRepr) *FQ {
+	out := &FQ{}
+	fqReduce(&r)
+	fqMul(&out.n, &r, &fqR2)
+	return out
+}
+
+// FQFromBytes converts a big-endian encoding of a number less than the
+// modulus into a field element.
+func FQFromBytes(b [48]byte) (*FQ, error) {
+	r := FQReprFromBytes(b)
+	if r.Cmp(qFieldModulusRepr) >= 0 {
+		return nil, errors.New("number is not less than the field modulus")
 	}
-	return &FQ{n: outN}
+	return FQReprToFQ(r), nil
+}
+
+// Bytes returns the canonical big-endian encoding of the field element.
+func (f FQ) Bytes() [48]byte {
+	return f.ToRepr().Bytes()
+}
+
+// ToRepr converts the field element out of Montgomery form.
+func (f FQ) ToRepr() FQRepr {
+	var t [12]uint64
+	copy(t[:], f.n[:])
+	var out FQRepr
+	fqMontReduce(&out, &t)
+	return out
+}
+
+// ToBig converts the field element to the underlying big number.
+func (f FQ) ToBig() *big.Int {
+	return f.ToRepr().ToBig()
 }
 
 // Copy creates a copy of the field element.
 func (f FQ) Copy() *FQ {
-	return &FQ{n: new(big.Int).Set(f.n)}
+	return &FQ{n: f.n}
 }
 
 // Add adds two field elements together.
 func (f FQ) Add(other *FQ) *FQ {
-	out := new(big.Int).Add(f.n, other.n)
-	if out.Cmp(QFieldModulus) >= 0 || out.Cmp(bigZero) < 0 {
-		out.Mod(out, QFieldModulus)
-	}
-	return &FQ{n: out}
+	out := &FQ{}
+	fqAdd(&out.n, &f.n, &other.n)
+	return out
 }
 
-// AddAssign multiplies a field element by this one.
-func (f FQ) AddAssign(other *FQ) {
-	f.n.Add(f.n, other.n)
-	if f.n.Cmp(QFieldModulus) >= 0 || f.n.Cmp(bigZero) < 0 {
-		f.n.Mod(f.n, QFieldModulus)
-	}
+// AddAssign adds a field element to this one.
+func (f *FQ) AddAssign(other *FQ) {
+	fqAdd(&f.n, &f.n, &other.n)
 }
 
 // Mul multiplies two field elements together.
 func (f FQ) Mul(other *FQ) *FQ {
-	out := new(big.Int).Mul(f.n, other.n)
-	out.Mod(out, QFieldModulus)
-	return &FQ{n: out}
+	out := &FQ{}
+	fqMul(&out.n, &f.n, &other.n)
+	return out
 }
 
 // MulAssign multiplies a field element by this one.
-func (f FQ) MulAssign(other *FQ) {
-	f.n.Mul(f.n, other.n)
-	if f.n.Cmp(QFieldModulus) < 0 && f.n.Cmp(bigZero) > 0 {
-		return
-	} else if f.n.BitLen() < 386 && f.n.Sign() == 1 {
-		for f.n.Cmp(QFieldModulus) >= 0 {
-			f.n.Sub(f.n, QFieldModulus)
-		}
-	} else {
-		f.n.Mod(f.n, QFieldModulus)
-	}
+func (f *FQ) MulAssign(other *FQ) {
+	fqMul(&f.n, &f.n, &other.n)
 }
 
 // Sub subtracts one field element from the other.
 func (f FQ) Sub(other *FQ) *FQ {
-	out := new(big.Int).Sub(f.n, other.n)
-	if out.Cmp(QFieldModulus) >= 0 || out.Cmp(bigZero) < 0 {
-		out.Mod(out, QFieldModulus)
-	}
-	return &FQ{n: out}
+	out := &FQ{}
+	fqSub(&out.n, &f.n, &other.n)
+	return out
 }
 
 // SubAssign subtracts a field element from this one.
-func (f FQ) SubAssign(other *FQ) {
-	f.n.Sub(f.n, other.n)
-	if f.n.Cmp(QFieldModulus) >= 0 || f.n.Cmp(bigZero) < 0 {
-		f.n.Mod(f.n, QFieldModulus)
-	}
+func (f *FQ) SubAssign(other *FQ) {
+	fqSub(&f.n, &f.n, &other.n)
 }
 
 // Div divides one field element by another.
 func (f FQ) Div(other *FQ) *FQ {
-	otherInverse := &FQ{n: primeFieldInv(other.n, QFieldModulus)}
+	otherInverse := other.Inverse()
+	if otherInverse == nil {
+		return FQZero.Copy()
+	}
 	return f.Mul(otherInverse)
 }
 
 // DivAssign divides one field element by another.
-func (f FQ) DivAssign(other *FQ) {
-	otherInverse := &FQ{n: primeFieldInv(other.n, QFieldModulus)}
+func (f *FQ) DivAssign(other *FQ) {
+	otherInverse := other.Inverse()
+	if otherInverse == nil {
+		*f = FQ{}
+		return
+	}
 	f.MulAssign(otherInverse)
 }
 
-// Exp exponentiates the field element to the given power.
+// Exp exponentiates the field element to the given power. The running
+// time depends on the exponent, so it must not be secret.
 func (f FQ) Exp(n *big.Int) *FQ {
-	return &FQ{new(big.Int).Exp(f.n, n, QFieldModulus)}
+	res := FQOne.Copy()
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		res.SquareAssign()
+		if n.Bit(i) == 1 {
+			res.MulAssign(&f)
+		}
+	}
+	return res
 }
 
 // ExpAssign exponentiates the field element to the given power.
 func (f *FQ) ExpAssign(n *big.Int) {
-	f.n.Exp(f.n, n, QFieldModulus)
+	*f = *f.Exp(n)
 }
 
-// Equals checks equality of two field elements.
+// Equals checks equality of two field elements in constant time.
 func (f FQ) Equals(other *FQ) bool {
-	return f.n.Cmp(other.n) == 0
+	return fqEqual(&f.n, &other.n) == 1
+}
+
+// ConditionalAssign sets the field element to other if choice is 1 and
+// leaves it unchanged if choice is 0. It does not branch on choice.
+func (f *FQ) ConditionalAssign(other *FQ, choice int) {
+	fqSelect(&f.n, &f.n, &other.n, uint64(choice))
 }
 
 // Neg gets the negative value of the field element mod QFieldModulus.
 func (f FQ) Neg() *FQ {
-	return NewFQ(new(big.Int).Neg(f.n))
+	out := &FQ{}
+	fqNeg(&out.n, &f.n)
+	return out
 }
 
 // NegAssign gets the negative value of the field element mod QFieldModulus.
 func (f *FQ) NegAssign() {
-	f.n.Neg(f.n)
-	f.n.Mod(f.n, QFieldModulus)
+	fqNeg(&f.n, &f.n)
 }
 
 func (f FQ) String() string {
-	return fmt.Sprintf("Fq(0x%096x)", f.n)
+	return fmt.Sprintf("Fq(0x%096x)", f.ToBig())
 }
 
 // Cmp compares this field element to another.
 func (f FQ) Cmp(other *FQ) int {
-	return f.n.Cmp(other.n)
+	return f.ToRepr().Cmp(other.ToRepr())
 }
 
 // Double doubles the element
 func (f FQ) Double() *FQ {
-	return NewFQ(new(big.Int).Lsh(f.n, 1))
+	out := &FQ{}
+	fqDouble(&out.n, &f.n)
+	return out
 }
 
 // DoubleAssign doubles the element
 func (f *FQ) DoubleAssign() {
-	f.n.Lsh(f.n, 1)
-	f.n.Mod(f.n, QFieldModulus)
+	fqDouble(&f.n, &f.n)
 }
 
 // IsZero checks if the field element is zero.
 func (f FQ) IsZero() bool {
-	return f.n.Cmp(bigZero) == 0
+	return fqEqual(&f.n, &FQRepr{}) == 1
 }
 
 // Square squares a field element.
 func (f FQ) Square() *FQ {
-	return NewFQ(new(big.Int).Mul(f.n, f.n))
+	out := &FQ{}
+	fqSquare(&out.n, &f.n)
+	return out
 }
 
 // SquareAssign squares a field element.
 func (f *FQ) SquareAssign() {
-	f.n.Mul(f.n, f.n)
-	f.n.Mod(f.n, QFieldModulus)
+	fqSquare(&f.n, &f.n)
 }
 
 var negativeOneFQ = NewFQ(negativeOne)
@@ -200,53 +226,21 @@ func (f FQ) Sqrt() *FQ {
 	a1.MulAssign(&f)
 	return a1
 }
+
 func isEven(b *big.Int) bool {
 	return b.Bit(0) == 0
 }
 
+// qMinus2 is QFieldModulus - 2.
+var qMinus2 = new(big.Int).Sub(QFieldModulus, bigTwo)
+
 // Inverse finds the inverse of the field element.
 func (f FQ) Inverse() *FQ {
 	if f.IsZero() {
 		return nil
 	}
-	u := new(big.Int).Set(f.n)
-	v := new(big.Int).Set(QFieldModulus)
-	b := NewFQ(new(big.Int).Set(bigOne))
-	c := FQZero.Copy()
-
-	for u.Cmp(bigOne) != 0 && v.Cmp(bigOne) != 0 {
-		for isEven(u) {
-			u.Div(u, bigTwo)
-			if isEven(b.n) {
-				b.n.Div(b.n, bigTwo)
-			} else {
-				b.n.Add(b.n, QFieldModulus)
-				b.n.Div(b.n, bigTwo)
-			}
-		}
-
-		for isEven(v) {
-			v.Div(v, bigTwo)
-			if isEven(c.n) {
-				c.n.Div(c.n, bigTwo)
-			} else {
-				c.n.Add(c.n, QFieldModulus)
-				c.n.Div(c.n, bigTwo)
-			}
-		}
-
-		if u.Cmp(v) >= 0 {
-			u.Sub(u, v)
-			b.SubAssign(c)
-		} else {
-			v.Sub(v, u)
-			c.SubAssign(b)
-		}
-	}
-	if u.Cmp(bigOne) == 0 {
-		return b
-	}
-	return c
+	// Fermat's little theorem runs in constant time unlike a binary GCD.
+	return f.Exp(qMinus2)
 }
 
 // Parity checks if the point is greater than the point negated.
@@ -267,7 +261,8 @@ func (f FQ) MulBits(b *big.Int) *FQ {
 	return res
 }
 
-// HashFQ calculates a new FQ2 value based on a hash.
+// HashFQ calculates a new FQ value based on a hash. It is kept for
+// compatibility; new code should use HashToFieldFQ.
 func HashFQ(hasher hash.Hash) *FQ {
 	digest := hasher.Sum(nil)
 	newB := new(big.Int).SetBytes(digest)
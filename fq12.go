@@ -63,6 +63,13 @@ func (f FQ12) Equals(other *FQ12) bool {
 	return f.c0.Equals(other.c0) && f.c1.Equals(other.c1)
 }
 
+// ConditionalAssign sets the element to other if choice is 1 and leaves
+// it unchanged if choice is 0. It does not branch on choice.
+func (f *FQ12) ConditionalAssign(other *FQ12, choice int) {
+	f.c0.ConditionalAssign(other.c0, choice)
+	f.c1.ConditionalAssign(other.c1, choice)
+}
+
 // Double doubles each coefficient in an FQ12 element.
 func (f FQ12) Double() *FQ12 {
 	return NewFQ12(f.c0.Double(), f.c1.Double())
@@ -240,3 +247,273 @@ func (f FQ12) Inverse() *FQ12 {
 
 	return NewFQ12(i.Mul(f.c0), i.Mul(f.c1).Neg())
 }
+
+// fq4Square squares a + b*s in Fq4 = Fq2[s]/(s^2 - (1 + u)) and returns the
+// two coefficients of the result.
+func fq4Square(a *FQ2, b *FQ2) (*FQ2, *FQ2) {
+	t0 := a.Square()
+	t1 := b.Square()
+	c0 := t1.MultiplyByNonresidue()
+	c0.AddAssign(t0)
+	c1 := a.Add(b)
+	c1.SquareAssign()
+	c1.SubAssign(t0)
+	c1.SubAssign(t1)
+	return c0, c1
+}
+
+// CyclotomicSquare squares an element of the cyclotomic subgroup of FQ12,
+// the elements f with f^(q^4 - q^2 + 1) = 1, using the Granger-Scott
+// formulas. The result is wrong for elements outside the subgroup. Every
+// output of FinalExponentiation's easy part is in the subgroup.
+func (f FQ12) CyclotomicSquare() *FQ12 {
+	// The element is viewed as three Fq4 elements (z0, z1), (z2, z3) and
+	// (z4, z5) over Fq4 = Fq2[s], s = w.
+	z0 := f.c0.c0
+	z4 := f.c0.c1
+	z3 := f.c0.c2
+	z2 := f.c1.c0
+	z1 := f.c1.c1
+	z5 := f.c1.c2
+
+	// tripleMinusDouble returns 3a - 2b and triplePlusDouble 3a + 2b.
+	tripleMinusDouble := func(a *FQ2, b *FQ2) *FQ2 {
+		out := a.Sub(b)
+		out.DoubleAssign()
+		out.AddAssign(a)
+		return out
+	}
+	triplePlusDouble := func(a *FQ2, b *FQ2) *FQ2 {
+		out := a.Add(b)
+		out.DoubleAssign()
+		out.AddAssign(a)
+		return out
+	}
+
+	t0, t1 := fq4Square(z0, z1)
+	c00 := tripleMinusDouble(t0, z0)
+	c11 := triplePlusDouble(t1, z1)
+
+	t0, t1 = fq4Square(z2, z3)
+	t2, t3 := fq4Square(z4, z5)
+	c01 := tripleMinusDouble(t0, z4)
+	c12 := triplePlusDouble(t1, z5)
+	c10 := triplePlusDouble(t3.MultiplyByNonresidue(), z2)
+	c02 := tripleMinusDouble(t2, z3)
+
+	return NewFQ12(NewFQ6(c00, c01, c02), NewFQ6(c10, c11, c12))
+}
+
+// CyclotomicExp raises an element of the cyclotomic subgroup to a
+// non-negative power using CyclotomicSquare. Like Exp, the running time
+// depends on the exponent.
+func (f FQ12) CyclotomicExp(n *big.Int) *FQ12 {
+	res := FQ12One.Copy()
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		res = res.CyclotomicSquare()
+		if n.Bit(i) == 1 {
+			res.MulAssign(&f)
+		}
+	}
+	return res
+}
+
+// cyclotomicSquareCompressed squares an element of the cyclotomic subgroup
+// in Karabina's compressed form, which only tracks g1 = c0.c1, g2 = c0.c2,
+// g3 = c1.c0 and g5 = c1.c2. The remaining coefficients of the output are
+// zero and must be recovered with decompressKarabina.
+func (f FQ12) cyclotomicSquareCompressed() *FQ12 {
+	g1 := f.c0.c1
+	g2 := f.c0.c2
+	g3 := f.c1.c0
+	g5 := f.c1.c2
+
+	g1Squared := g1.Square()
+	g2Squared := g2.Square()
+	g3Squared := g3.Square()
+	g5Squared := g5.Square()
+
+	// 2 * g1 * g5 and 2 * g2 * g3
+	g1g5 := g1.Add(g5)
+	g1g5.SquareAssign()
+	g1g5.SubAssign(g1Squared)
+	g1g5.SubAssign(g5Squared)
+	g2g3 := g2.Add(g3)
+	g2g3.SquareAssign()
+	g2g3.SubAssign(g2Squared)
+	g2g3.SubAssign(g3Squared)
+
+	// h1 = 3 * (g3^2 + nr * g2^2) - 2 * g1
+	t := g2Squared.MultiplyByNonresidue()
+	t.AddAssign(g3Squared)
+	h1 := t.Sub(g1)
+	h1.DoubleAssign()
+	h1.AddAssign(t)
+
+	// h2 = 3 * (g1^2 + nr * g5^2) - 2 * g2
+	t = g5Squared.MultiplyByNonresidue()
+	t.AddAssign(g1Squared)
+	h2 := t.Sub(g2)
+	h2.DoubleAssign()
+	h2.AddAssign(t)
+
+	// h3 = 3 * nr * 2 * g1 * g5 + 2 * g3
+	t = g1g5.MultiplyByNonresidue()
+	h3 := t.Add(g3)
+	h3.DoubleAssign()
+	h3.AddAssign(t)
+
+	// h5 = 3 * 2 * g2 * g3 + 2 * g5
+	h5 := g2g3.Add(g5)
+	h5.DoubleAssign()
+	h5.AddAssign(g2g3)
+
+	return NewFQ12(
+		NewFQ6(FQ2Zero.Copy(), h1, h2),
+		NewFQ6(h3, FQ2Zero.Copy(), h5),
+	)
+}
+
+// decompressKarabina recovers g0 and g4 for a list of compressed cyclotomic
+// elements in place, sharing a single inversion between them.
+//
+// Neither formula for g4 applies when g2 = g3 = 0. For an element of the
+// cyclotomic subgroup this forces g1 = g5 = 0 as well, so the element lies in
+// the subfield FQ2[vw] of order q^4. That subfield meets the cyclotomic
+// subgroup, of order q^4 - q^2 + 1, only in the identity (Karabina, Theorem
+// 3.1), so such an element is decompressed to one.
+func decompressKarabina(elems []*FQ12) {
+	nums := make([]*FQ2, len(elems))
+	dens := make([]*FQ2, len(elems))
+	isOne := make([]bool, len(elems))
+	for i, e := range elems {
+		g1 := e.c0.c1
+		g2 := e.c0.c2
+		g3 := e.c1.c0
+		g5 := e.c1.c2
+		if g3.IsZero() {
+			// g4 = 2 * g1 * g5 / g2
+			nums[i] = g1.Mul(g5)
+			nums[i].DoubleAssign()
+			dens[i] = g2.Copy()
+			if g2.IsZero() {
+				// g2 = g3 = 0 only for the identity, see above
+				isOne[i] = true
+				dens[i] = FQ2One.Copy()
+			}
+		} else {
+			// g4 = (nr * g5^2 + 3 * g1^2 - 2 * g2) / (4 * g3)
+			g1Squared := g1.Square()
+			nums[i] = g1Squared.Sub(g2)
+			nums[i].DoubleAssign()
+			nums[i].AddAssign(g1Squared)
+			nums[i].AddAssign(g5.Square().MultiplyByNonresidue())
+			dens[i] = g3.Double()
+			dens[i].DoubleAssign()
+		}
+	}
+
+	// Montgomery's trick: invert the product of all denominators once.
+	prefix := make([]*FQ2, len(elems))
+	acc := FQ2One.Copy()
+	for i := range dens {
+		prefix[i] = acc.Copy()
+		acc.MulAssign(dens[i])
+	}
+	acc = acc.Inverse()
+	for i := len(dens) - 1; i >= 0; i-- {
+		denInverse := acc.Mul(prefix[i])
+		acc.MulAssign(dens[i])
+		nums[i].MulAssign(denInverse)
+	}
+
+	for i, e := range elems {
+		if isOne[i] {
+			*e = *FQ12One.Copy()
+			continue
+		}
+		g1 := e.c0.c1
+		g2 := e.c0.c2
+		g3 := e.c1.c0
+		g5 := e.c1.c2
+		g4 := nums[i]
+
+		// g0 = nr * (2 * g4^2 + g3 * g5 - 3 * g1 * g2) + 1
+		g1g2 := g1.Mul(g2)
+		g0 := g4.Square()
+		g0.SubAssign(g1g2)
+		g0.DoubleAssign()
+		g0.SubAssign(g1g2)
+		g0.AddAssign(g3.Mul(g5))
+		g0.MultiplyByNonresidueAssign()
+		g0.AddAssign(FQ2One)
+
+		e.c0.c0 = g0
+		e.c1.c1 = g4
+	}
+}
+
+// cyclotomicExpCompressed raises an element of the cyclotomic subgroup to a
+// non-negative power. The squarings are done in Karabina's compressed form
+// and only the powers needed for set bits of n are decompressed, which is
+// much cheaper than CyclotomicExp for sparse exponents like the curve
+// parameter x.
+func (f FQ12) cyclotomicExpCompressed(n *big.Int) *FQ12 {
+	res := FQ12One.Copy()
+	if n.Bit(0) == 1 {
+		res.MulAssign(&f)
+	}
+
+	var powers []*FQ12
+	sq := &f
+	for i := 1; i < n.BitLen(); i++ {
+		sq = sq.cyclotomicSquareCompressed()
+		if n.Bit(i) == 1 {
+			powers = append(powers, sq)
+		}
+	}
+	decompressKarabina(powers)
+	for _, p := range powers {
+		res.MulAssign(p)
+	}
+	return res
+}
+
+// coefficients returns the twelve FQ coefficients of the element in tower
+// order.
+func (f FQ12) coefficients() [12]*FQ {
+	return [12]*FQ{
+		f.c0.c0.c0, f.c0.c0.c1, f.c0.c1.c0, f.c0.c1.c1, f.c0.c2.c0, f.c0.c2.c1,
+		f.c1.c0.c0, f.c1.c0.c1, f.c1.c1.c0, f.c1.c1.c1, f.c1.c2.c0, f.c1.c2.c1,
+	}
+}
+
+// fq6V is v as an FQ6 element.
+var fq6V = NewFQ6(FQ2Zero, FQ2One, FQ2Zero)
+
+// torusCompress maps an element c0 + c1*w with norm one over FQ6 onto the
+// torus T2(FQ6) as g = (1 + c0) / c1. Both one and minus one have c1 = 0;
+// one is mapped to zero and minus one must not be passed in.
+func (f FQ12) torusCompress() *FQ6 {
+	if f.c1.IsZero() {
+		return FQ6Zero.Copy()
+	}
+	g := f.c0.Add(FQ6One)
+	g.MulAssign(f.c1.Inverse())
+	return g
+}
+
+// torusDecompress inverts torusCompress by computing
+// (g + w) / (g - w) = (g^2 + v + 2g*w) / (g^2 - v).
+func torusDecompress(g *FQ6) *FQ12 {
+	if g.IsZero() {
+		return FQ12One.Copy()
+	}
+	g2 := g.Square()
+	inv := g2.Sub(fq6V).Inverse()
+	c0 := g2.Add(fq6V)
+	c0.MulAssign(inv)
+	c1 := g.Double()
+	c1.MulAssign(inv)
+	return NewFQ12(c0, c1)
+}
@@ -0,0 +1,94 @@
+package bls
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// randCyclotomic returns a random element of the cyclotomic subgroup by
+// applying the easy part of the final exponentiation.
+func randCyclotomic(t *testing.T) *FQ12 {
+	f, err := RandFQ12(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return easyPart(f)
+}
+
+func easyPart(f *FQ12) *FQ12 {
+	f = f.Conjugate().Mul(f.Inverse())
+	return f.FrobeniusMap(2).Mul(f)
+}
+
+func cyclotomicExponents(t *testing.T) []*big.Int {
+	exponents := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), blsX}
+	for i := 0; i < 5; i++ {
+		n, err := rand.Int(rand.Reader, RFieldModulus)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exponents = append(exponents, n)
+	}
+	return exponents
+}
+
+func TestFQ12CyclotomicExpCompressed(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		f := randCyclotomic(t)
+		for _, n := range cyclotomicExponents(t) {
+			if !f.cyclotomicExpCompressed(n).Equals(f.Exp(n)) {
+				t.Fatalf("cyclotomicExpCompressed(%x) does not match Exp", n)
+			}
+		}
+	}
+}
+
+func TestFQ12CyclotomicExpCompressedG2G3Zero(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		g0, err := RandFQ2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g4, err := RandFQ2(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// g0 + g4 * vw has g1 = g2 = g3 = g5 = 0 but is not one.
+		f := NewFQ12(
+			NewFQ6(g0, FQ2Zero.Copy(), FQ2Zero.Copy()),
+			NewFQ6(FQ2Zero.Copy(), g4, FQ2Zero.Copy()),
+		)
+		if f.Equals(FQ12One) {
+			t.Fatal("expected an element other than one")
+		}
+
+		// It is not in the cyclotomic subgroup: its projection there is one.
+		if !easyPart(f).Equals(FQ12One) {
+			t.Fatal("element with g2 = g3 = 0 should project to one")
+		}
+
+		compressed := f.Copy()
+		decompressKarabina([]*FQ12{compressed})
+		if !compressed.Equals(FQ12One) {
+			t.Fatal("element with g2 = g3 = 0 should decompress to one")
+		}
+
+		// Decompressing it alongside other elements must not disturb them.
+		g := randCyclotomic(t)
+		gCompressed := g.Copy()
+		gCompressed.c0.c0 = FQ2Zero.Copy()
+		gCompressed.c1.c1 = FQ2Zero.Copy()
+		decompressKarabina([]*FQ12{f.Copy(), gCompressed})
+		if !gCompressed.Equals(g) {
+			t.Fatal("decompression alongside an identity does not match")
+		}
+	}
+
+	for _, n := range cyclotomicExponents(t) {
+		if !FQ12One.cyclotomicExpCompressed(n).Equals(FQ12One) {
+			t.Fatalf("cyclotomicExpCompressed(%x) of one should be one", n)
+		}
+	}
+}
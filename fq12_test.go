@@ -2,6 +2,7 @@ package bls_test
 
 import (
 	"crypto/rand"
+	"io"
 	"testing"
 
 	"github.com/phoreproject/bls"
@@ -160,3 +161,65 @@ func BenchmarkFQ12Inverse(b *testing.B) {
 		count = (count + 1) % g1MulAssignSamples
 	}
 }
+
+// randCyclotomic returns a random element of the cyclotomic subgroup by
+// applying the easy part of the final exponentiation.
+func randCyclotomic(r io.Reader) *bls.FQ12 {
+	f, _ := bls.RandFQ12(r)
+	f = f.Conjugate().Mul(f.Inverse())
+	return f.FrobeniusMap(2).Mul(f)
+}
+
+func TestFQ12CyclotomicSquare(t *testing.T) {
+	r := NewXORShift(1)
+	for i := 0; i < 100; i++ {
+		f := randCyclotomic(r)
+		if !f.CyclotomicSquare().Equals(f.Square()) {
+			t.Fatal("cyclotomic square does not match square")
+		}
+	}
+	if !bls.FQ12One.CyclotomicSquare().Equals(bls.FQ12One) {
+		t.Fatal("cyclotomic square of one should be one")
+	}
+}
+
+func TestFQ12CyclotomicExp(t *testing.T) {
+	r := NewXORShift(1)
+	for i := 0; i < 20; i++ {
+		f := randCyclotomic(r)
+		n, _ := rand.Int(r, bls.RFieldModulus)
+		if !f.CyclotomicExp(n).Equals(f.Exp(n)) {
+			t.Fatal("cyclotomic exponentiation does not match exponentiation")
+		}
+	}
+}
+
+func TestFinalExponentiationBilinear(t *testing.T) {
+	r := NewXORShift(1)
+	a, _ := bls.RandFR(r)
+	b, _ := bls.RandFR(r)
+	p, _ := bls.RandG1(r)
+	q, _ := bls.RandG2(r)
+
+	left := bls.Pairing(p.MulFR(a), q.MulFR(b))
+	right := bls.Pairing(p, q).Exp(a.Mul(b).ToBig())
+	if !left.Equals(right) {
+		t.Fatal("pairing is not bilinear")
+	}
+}
+
+func BenchmarkFQ12CyclotomicSquare(b *testing.B) {
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]*bls.FQ12{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		inData[i] = randCyclotomic(r)
+	}
+
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		inData[count].CyclotomicSquare()
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
@@ -291,9 +291,16 @@ func (f *FQ2) ExpAssign(n *big.Int) {
 // -(2**384 mod q) mod q
 var negativeOne, _ = new(big.Int).SetString("4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559786", 10)
 
-// Equals checks if this FQ2 equals another one.
+// Equals checks if this FQ2 equals another one in constant time.
 func (f FQ2) Equals(other *FQ2) bool {
-	return f.Cmp(other) == 0
+	return fqEqual(&f.c0.n, &other.c0.n)&fqEqual(&f.c1.n, &other.c1.n) == 1
+}
+
+// ConditionalAssign sets the element to other if choice is 1 and leaves
+// it unchanged if choice is 0. It does not branch on choice.
+func (f *FQ2) ConditionalAssign(other *FQ2, choice int) {
+	f.c0.ConditionalAssign(other.c0, choice)
+	f.c1.ConditionalAssign(other.c1, choice)
 }
 
 // Sqrt finds the sqrt of a field element.
@@ -367,7 +374,8 @@ func (f FQ2) MulBits(b *big.Int) *FQ2 {
 	return res
 }
 
-// HashFQ2 calculates a new FQ2 value based on a hash.
+// HashFQ2 calculates a new FQ2 value based on a hash. It is kept for
+// compatibility; new code should use HashToFieldFQ2.
 func HashFQ2(hasher hash.Hash) *FQ2 {
 	digest := hasher.Sum(nil)
 	newB := new(big.Int).SetBytes(digest)
@@ -111,6 +111,14 @@ func (f FQ6) Equals(other *FQ6) bool {
 	return f.c0.Equals(other.c0) && f.c1.Equals(other.c1) && f.c2.Equals(other.c2)
 }
 
+// ConditionalAssign sets the element to other if choice is 1 and leaves
+// it unchanged if choice is 0. It does not branch on choice.
+func (f *FQ6) ConditionalAssign(other *FQ6, choice int) {
+	f.c0.ConditionalAssign(other.c0, choice)
+	f.c1.ConditionalAssign(other.c1, choice)
+	f.c2.ConditionalAssign(other.c2, choice)
+}
+
 // IsZero checks if the FQ6 element is zero.
 func (f FQ6) IsZero() bool {
 	return f.Equals(FQ6Zero)
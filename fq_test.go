@@ -1,11 +1,133 @@
 package bls_test
 
 import (
+	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/phoreproject/bls"
 )
 
+func TestFQArithmeticAgainstBig(t *testing.T) {
+	q := bls.QFieldModulus
+	for i := 0; i < 1000; i++ {
+		a, _ := rand.Int(rand.Reader, q)
+		b, _ := rand.Int(rand.Reader, q)
+		fa := bls.NewFQ(a)
+		fb := bls.NewFQ(b)
+
+		if fa.ToBig().Cmp(a) != 0 {
+			t.Fatal("FQ does not round-trip through big.Int")
+		}
+
+		expected := new(big.Int).Add(a, b)
+		expected.Mod(expected, q)
+		if fa.Add(fb).ToBig().Cmp(expected) != 0 {
+			t.Fatal("FQ Add does not match big.Int")
+		}
+
+		expected = new(big.Int).Sub(a, b)
+		expected.Mod(expected, q)
+		if fa.Sub(fb).ToBig().Cmp(expected) != 0 {
+			t.Fatal("FQ Sub does not match big.Int")
+		}
+
+		expected = new(big.Int).Mul(a, b)
+		expected.Mod(expected, q)
+		if fa.Mul(fb).ToBig().Cmp(expected) != 0 {
+			t.Fatal("FQ Mul does not match big.Int")
+		}
+
+		expected = new(big.Int).Mul(a, a)
+		expected.Mod(expected, q)
+		if fa.Square().ToBig().Cmp(expected) != 0 {
+			t.Fatal("FQ Square does not match big.Int")
+		}
+
+		expected = new(big.Int).Neg(a)
+		expected.Mod(expected, q)
+		if fa.Neg().ToBig().Cmp(expected) != 0 {
+			t.Fatal("FQ Neg does not match big.Int")
+		}
+
+		expected = new(big.Int).Lsh(a, 1)
+		expected.Mod(expected, q)
+		if fa.Double().ToBig().Cmp(expected) != 0 {
+			t.Fatal("FQ Double does not match big.Int")
+		}
+
+		if fa.Cmp(fb) != a.Cmp(b) {
+			t.Fatal("FQ Cmp does not match big.Int")
+		}
+	}
+}
+
+func TestFQInverse(t *testing.T) {
+	if bls.FQZero.Inverse() != nil {
+		t.Fatal("inverse of zero should be nil")
+	}
+	for i := 0; i < 100; i++ {
+		a, err := bls.RandFQ(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.IsZero() {
+			continue
+		}
+		expected := new(big.Int).ModInverse(a.ToBig(), bls.QFieldModulus)
+		if a.Inverse().ToBig().Cmp(expected) != 0 {
+			t.Fatal("FQ Inverse does not match big.Int")
+		}
+		if !a.Mul(a.Inverse()).Equals(bls.FQOne) {
+			t.Fatal("a * a^-1 != 1")
+		}
+	}
+}
+
+func TestFQExp(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		a, _ := rand.Int(rand.Reader, bls.QFieldModulus)
+		e, _ := rand.Int(rand.Reader, bls.QFieldModulus)
+		expected := new(big.Int).Exp(a, e, bls.QFieldModulus)
+		if bls.NewFQ(a).Exp(e).ToBig().Cmp(expected) != 0 {
+			t.Fatal("FQ Exp does not match big.Int")
+		}
+	}
+}
+
+func TestFQSqrt(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a, err := bls.RandFQ(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a2 := a.Square()
+		if a2.Legendre() == bls.LegendreQuadraticNonResidue {
+			t.Fatal("square is a quadratic non-residue")
+		}
+		s := a2.Sqrt()
+		if s == nil || !s.Square().Equals(a2) {
+			t.Fatal("sqrt(a^2)^2 != a^2")
+		}
+	}
+}
+
+func TestFQReprBytes(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a, _ := rand.Int(rand.Reader, bls.QFieldModulus)
+		r, err := bls.FQReprFromBig(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.ToBig().Cmp(a) != 0 {
+			t.Fatal("FQRepr does not round-trip through big.Int")
+		}
+		if bls.FQReprFromBytes(r.Bytes()) != r {
+			t.Fatal("FQRepr does not round-trip through bytes")
+		}
+	}
+}
+
 func BenchmarkFQAdd(b *testing.B) {
 	type addData struct {
 		f1 *bls.FQ
@@ -224,3 +346,120 @@ func BenchmarkFQSqrt(b *testing.B) {
 		count = (count + 1) % g1MulAssignSamples
 	}
 }
+
+func BenchmarkFQMul(b *testing.B) {
+	type mulData struct {
+		f1 *bls.FQ
+		f2 *bls.FQ
+	}
+
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]mulData{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		f1, _ := bls.RandFQ(r)
+		f2, _ := bls.RandFQ(r)
+		inData[i] = mulData{
+			f1: f1,
+			f2: f2,
+		}
+	}
+
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		inData[count].f1.Mul(inData[count].f2)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
+
+func BenchmarkFQMulAssign(b *testing.B) {
+	type mulData struct {
+		f1 *bls.FQ
+		f2 *bls.FQ
+	}
+
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]mulData{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		f1, _ := bls.RandFQ(r)
+		f2, _ := bls.RandFQ(r)
+		inData[i] = mulData{
+			f1: f1,
+			f2: f2,
+		}
+	}
+
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		inData[count].f1.MulAssign(inData[count].f2)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
+
+// BenchmarkBigMulMod is the math/big baseline for BenchmarkFQMul.
+func BenchmarkBigMulMod(b *testing.B) {
+	type mulData struct {
+		f1 *big.Int
+		f2 *big.Int
+	}
+
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]mulData{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		f1, _ := rand.Int(r, bls.QFieldModulus)
+		f2, _ := rand.Int(r, bls.QFieldModulus)
+		inData[i] = mulData{
+			f1: f1,
+			f2: f2,
+		}
+	}
+
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		out := new(big.Int).Mul(inData[count].f1, inData[count].f2)
+		out.Mod(out, bls.QFieldModulus)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
+
+// BenchmarkBigInverse is the math/big baseline for BenchmarkFQInverse.
+func BenchmarkBigInverse(b *testing.B) {
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]*big.Int{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		inData[i], _ = rand.Int(r, bls.QFieldModulus)
+	}
+
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		new(big.Int).ModInverse(inData[count], bls.QFieldModulus)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
+
+func TestFQBytes(t *testing.T) {
+	r := NewXORShift(1)
+	for i := 0; i < 100; i++ {
+		f, _ := bls.RandFQ(r)
+		out, err := bls.FQFromBytes(f.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !out.Equals(f) {
+			t.Fatal("FQ did not round-trip through bytes")
+		}
+	}
+
+	var q [48]byte
+	bls.QFieldModulus.FillBytes(q[:])
+	if _, err := bls.FQFromBytes(q); err == nil {
+		t.Fatal("expected the modulus to be rejected")
+	}
+}
@@ -0,0 +1,181 @@
+package bls
+
+import "math/bits"
+
+// qFieldModulusRepr is QFieldModulus as limbs.
+var qFieldModulusRepr = FQRepr{
+	0xb9feffffffffaaab,
+	0x1eabfffeb153ffff,
+	0x6730d2a0f6b0f624,
+	0x64774b84f38512bf,
+	0x4b1ba7b6434bacd7,
+	0x1a0111ea397fe69a,
+}
+
+// fqR is 2^384 mod q, the Montgomery form of one.
+var fqR = FQRepr{
+	0x760900000002fffd,
+	0xebf4000bc40c0002,
+	0x5f48985753c758ba,
+	0x77ce585370525745,
+	0x5c071a97a256ec6d,
+	0x15f65ec3fa80e493,
+}
+
+// fqR2 is 2^768 mod q, used to convert into Montgomery form.
+var fqR2 = FQRepr{
+	0xf4df1f341c341746,
+	0x0a76e6a609d104f1,
+	0x8de5476c4c95b6d5,
+	0x67eb88a9939d83c0,
+	0x9a793e85b519952d,
+	0x11988fe592cae3aa,
+}
+
+// fqInv is -q^-1 mod 2^64.
+const fqInv = 0x89f3fffcfffcfffd
+
+// The base field arithmetic below never branches on or indexes memory by
+// the value of its operands, so it is safe to use on secret values.
+
+// fqSelect sets out to a if choice is 0 and to b if choice is 1.
+func fqSelect(out, a, b *FQRepr, choice uint64) {
+	mask := -choice
+	for i := 0; i < 6; i++ {
+		out[i] = a[i] ^ (mask & (a[i] ^ b[i]))
+	}
+}
+
+// fqEqual returns 1 if a == b and 0 otherwise.
+func fqEqual(a, b *FQRepr) uint64 {
+	var d uint64
+	for i := 0; i < 6; i++ {
+		d |= a[i] ^ b[i]
+	}
+	// d | -d has its top bit set iff d != 0
+	return 1 ^ ((d | -d) >> 63)
+}
+
+// fqReduce subtracts the modulus once if a >= q.
+func fqReduce(a *FQRepr) {
+	t := *a
+	fqSelect(a, &t, a, fqSubBorrow(&t, &qFieldModulusRepr))
+}
+
+// fqSubBorrow sets a = a - b and returns the borrow as 0 or 1.
+func fqSubBorrow(a, b *FQRepr) uint64 {
+	var borrow uint64
+	a[0], borrow = bits.Sub64(a[0], b[0], 0)
+	a[1], borrow = bits.Sub64(a[1], b[1], borrow)
+	a[2], borrow = bits.Sub64(a[2], b[2], borrow)
+	a[3], borrow = bits.Sub64(a[3], b[3], borrow)
+	a[4], borrow = bits.Sub64(a[4], b[4], borrow)
+	a[5], borrow = bits.Sub64(a[5], b[5], borrow)
+	return borrow
+}
+
+// fqAdd sets out = a + b mod q.
+func fqAdd(out, a, b *FQRepr) {
+	*out = *a
+	out.AddNoCarry(*b)
+	fqReduce(out)
+}
+
+// fqSub sets out = a - b mod q.
+func fqSub(out, a, b *FQRepr) {
+	*out = *a
+	mask := -fqSubBorrow(out, b)
+	out.AddNoCarry(FQRepr{
+		qFieldModulusRepr[0] & mask,
+		qFieldModulusRepr[1] & mask,
+		qFieldModulusRepr[2] & mask,
+		qFieldModulusRepr[3] & mask,
+		qFieldModulusRepr[4] & mask,
+		qFieldModulusRepr[5] & mask,
+	})
+}
+
+// fqDouble sets out = 2a mod q.
+func fqDouble(out, a *FQRepr) {
+	*out = *a
+	out.Mul2()
+	fqReduce(out)
+}
+
+// fqNeg sets out = -a mod q.
+func fqNeg(out, a *FQRepr) {
+	isZero := fqEqual(a, &FQRepr{})
+	tmp := qFieldModulusRepr
+	tmp.SubNoBorrow(*a)
+	fqSelect(out, &tmp, &FQRepr{}, isZero)
+}
+
+// mulAddAdd returns the 128-bit value a*b + c + d as (hi, lo).
+func mulAddAdd(a, b, c, d uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(a, b)
+	var carry uint64
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, d, 0)
+	hi += carry
+	return hi, lo
+}
+
+// fqMontReduceGeneric performs Montgomery reduction of the 768-bit
+// product t, setting out = t * 2^-384 mod q.
+func fqMontReduceGeneric(out *FQRepr, t *[12]uint64) {
+	var carry2 uint64
+	for i := 0; i < 6; i++ {
+		m := t[i] * fqInv
+		var c uint64
+		for j := 0; j < 6; j++ {
+			c, t[i+j] = mulAddAdd(m, qFieldModulusRepr[j], t[i+j], c)
+		}
+		t[i+6], carry2 = bits.Add64(t[i+6], c, carry2)
+	}
+	copy(out[:], t[6:])
+	fqReduce(out)
+}
+
+// fqMulGeneric sets out = a * b * 2^-384 mod q.
+func fqMulGeneric(out, a, b *FQRepr) {
+	var t [12]uint64
+	for i := 0; i < 6; i++ {
+		var c uint64
+		for j := 0; j < 6; j++ {
+			c, t[i+j] = mulAddAdd(a[i], b[j], t[i+j], c)
+		}
+		t[i+6] = c
+	}
+	fqMontReduceGeneric(out, &t)
+}
+
+// fqSquareGeneric sets out = a * a * 2^-384 mod q.
+func fqSquareGeneric(out, a *FQRepr) {
+	var t [12]uint64
+
+	// off-diagonal products
+	for i := 0; i < 5; i++ {
+		var c uint64
+		for j := i + 1; j < 6; j++ {
+			c, t[i+j] = mulAddAdd(a[i], a[j], t[i+j], c)
+		}
+		t[i+6] = c
+	}
+
+	// double them
+	t[11] = t[10] >> 63
+	for i := 10; i > 0; i-- {
+		t[i] = t[i]<<1 | t[i-1]>>63
+	}
+	t[0] <<= 1
+
+	// add the diagonal
+	var carry uint64
+	for i := 0; i < 6; i++ {
+		hi, lo := bits.Mul64(a[i], a[i])
+		t[2*i], carry = bits.Add64(t[2*i], lo, carry)
+		t[2*i+1], carry = bits.Add64(t[2*i+1], hi, carry)
+	}
+	fqMontReduceGeneric(out, &t)
+}
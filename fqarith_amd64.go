@@ -0,0 +1,46 @@
+//go:build amd64 && !purego
+
+package bls
+
+import "golang.org/x/sys/cpu"
+
+// useADX is set if the CPU supports the MULX, ADCX and ADOX instructions
+// used by the assembly kernels.
+var useADX = cpu.X86.HasBMI2 && cpu.X86.HasADX
+
+//go:noescape
+func fqMulADX(out, a, b *FQRepr)
+
+//go:noescape
+func fqSquareADX(out, a *FQRepr)
+
+//go:noescape
+func fqMontReduceADX(out *FQRepr, t *[12]uint64)
+
+// fqMul sets out = a * b * 2^-384 mod q.
+func fqMul(out, a, b *FQRepr) {
+	if useADX {
+		fqMulADX(out, a, b)
+		return
+	}
+	fqMulGeneric(out, a, b)
+}
+
+// fqSquare sets out = a * a * 2^-384 mod q.
+func fqSquare(out, a *FQRepr) {
+	if useADX {
+		fqSquareADX(out, a)
+		return
+	}
+	fqSquareGeneric(out, a)
+}
+
+// fqMontReduce performs Montgomery reduction of the 768-bit product t,
+// setting out = t * 2^-384 mod q.
+func fqMontReduce(out *FQRepr, t *[12]uint64) {
+	if useADX {
+		fqMontReduceADX(out, t)
+		return
+	}
+	fqMontReduceGeneric(out, t)
+}
@@ -0,0 +1,911 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// Montgomery multiplication and reduction modulo the BLS12-381 base field
+// prime using the BMI2 MULX and ADX ADCX/ADOX instructions. ADCX and ADOX
+// carry through CF and OF respectively, which lets the low and high halves
+// of each row of partial products be accumulated in two independent carry
+// chains.
+
+// func fqMulADX(out, a, b *FQRepr)
+TEXT ·fqMulADX(SB), NOSPLIT, $0-24
+	MOVQ a+8(FP), SI
+	MOVQ b+16(FP), DI
+	XORQ R8, R8
+	XORQ R9, R9
+	XORQ R10, R10
+	XORQ R11, R11
+	XORQ R12, R12
+	XORQ R13, R13
+	XORQ R14, R14
+
+	// t += a * b[0]
+	MOVQ 0(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	ADCQ $0, R14
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R8, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	ADCQ $0, R14
+
+	// t += a * b[1]
+	MOVQ 8(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	ADCQ $0, R8
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R9, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	ADCQ $0, R8
+
+	// t += a * b[2]
+	MOVQ 16(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	ADCQ $0, R9
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R10, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	ADCQ $0, R9
+
+	// t += a * b[3]
+	MOVQ 24(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	ADCQ $0, R10
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R11, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	ADCQ $0, R10
+
+	// t += a * b[4]
+	MOVQ 32(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	ADCQ $0, R11
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R12, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	ADCQ $0, R11
+
+	// t += a * b[5]
+	MOVQ 40(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	ADCQ $0, R12
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R13, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	ADCQ $0, R12
+
+	// subtract the modulus if the result is not less than it
+	MOVQ R14, AX
+	MOVQ R8, BX
+	MOVQ R9, CX
+	MOVQ R10, DX
+	MOVQ R11, SI
+	MOVQ R12, DI
+	SUBQ ·qFieldModulusRepr+0(SB), AX
+	SBBQ ·qFieldModulusRepr+8(SB), BX
+	SBBQ ·qFieldModulusRepr+16(SB), CX
+	SBBQ ·qFieldModulusRepr+24(SB), DX
+	SBBQ ·qFieldModulusRepr+32(SB), SI
+	SBBQ ·qFieldModulusRepr+40(SB), DI
+	CMOVQCS R14, AX
+	CMOVQCS R8, BX
+	CMOVQCS R9, CX
+	CMOVQCS R10, DX
+	CMOVQCS R11, SI
+	CMOVQCS R12, DI
+
+	MOVQ out+0(FP), R13
+	MOVQ AX, 0(R13)
+	MOVQ BX, 8(R13)
+	MOVQ CX, 16(R13)
+	MOVQ DX, 24(R13)
+	MOVQ SI, 32(R13)
+	MOVQ DI, 40(R13)
+	RET
+
+// func fqSquareADX(out, a *FQRepr)
+TEXT ·fqSquareADX(SB), NOSPLIT, $0-16
+	MOVQ a+8(FP), SI
+	MOVQ SI, DI
+	XORQ R8, R8
+	XORQ R9, R9
+	XORQ R10, R10
+	XORQ R11, R11
+	XORQ R12, R12
+	XORQ R13, R13
+	XORQ R14, R14
+
+	// t += a * b[0]
+	MOVQ 0(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	ADCQ $0, R14
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R8, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	ADCQ $0, R14
+
+	// t += a * b[1]
+	MOVQ 8(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	ADCQ $0, R8
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R9, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	ADCQ $0, R8
+
+	// t += a * b[2]
+	MOVQ 16(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	ADCQ $0, R9
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R10, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	ADCQ $0, R9
+
+	// t += a * b[3]
+	MOVQ 24(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	ADCQ $0, R10
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R11, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	ADCQ $0, R10
+
+	// t += a * b[4]
+	MOVQ 32(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	ADCQ $0, R11
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R12, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	ADCQ $0, R11
+
+	// t += a * b[5]
+	MOVQ 40(DI), DX
+	XORQ AX, AX
+	MULXQ 0(SI), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ 8(SI), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ 16(SI), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ 24(SI), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ 32(SI), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ 40(SI), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	ADCQ $0, R12
+
+	// m = t[0] * -q^-1 mod 2^64
+	MOVQ R13, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+
+	// t = (t + m * q) / 2^64
+	XORQ AX, AX
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	ADCQ $0, R12
+
+	// subtract the modulus if the result is not less than it
+	MOVQ R14, AX
+	MOVQ R8, BX
+	MOVQ R9, CX
+	MOVQ R10, DX
+	MOVQ R11, SI
+	MOVQ R12, DI
+	SUBQ ·qFieldModulusRepr+0(SB), AX
+	SBBQ ·qFieldModulusRepr+8(SB), BX
+	SBBQ ·qFieldModulusRepr+16(SB), CX
+	SBBQ ·qFieldModulusRepr+24(SB), DX
+	SBBQ ·qFieldModulusRepr+32(SB), SI
+	SBBQ ·qFieldModulusRepr+40(SB), DI
+	CMOVQCS R14, AX
+	CMOVQCS R8, BX
+	CMOVQCS R9, CX
+	CMOVQCS R10, DX
+	CMOVQCS R11, SI
+	CMOVQCS R12, DI
+
+	MOVQ out+0(FP), R13
+	MOVQ AX, 0(R13)
+	MOVQ BX, 8(R13)
+	MOVQ CX, 16(R13)
+	MOVQ DX, 24(R13)
+	MOVQ SI, 32(R13)
+	MOVQ DI, 40(R13)
+	RET
+
+// func fqMontReduceADX(out *FQRepr, t *[12]uint64)
+TEXT ·fqMontReduceADX(SB), NOSPLIT, $0-16
+	MOVQ t+8(FP), SI
+	MOVQ 0(SI), R8
+	MOVQ 8(SI), R9
+	MOVQ 16(SI), R10
+	MOVQ 24(SI), R11
+	MOVQ 32(SI), R12
+	MOVQ 40(SI), R13
+	XORQ CX, CX
+
+	// t[0:7] += m * q where m = t[0] * -q^-1 mod 2^64
+	MOVQ 48(SI), R14
+	MOVQ R8, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+	XORQ DI, DI
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, DI
+	ADCQ $0, DI
+	ADDQ CX, DI
+	ADDQ DI, R14
+	SBBQ CX, CX
+	NEGQ CX
+
+	// t[1:8] += m * q where m = t[1] * -q^-1 mod 2^64
+	MOVQ 56(SI), R8
+	MOVQ R9, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+	XORQ DI, DI
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, DI
+	ADCQ $0, DI
+	ADDQ CX, DI
+	ADDQ DI, R8
+	SBBQ CX, CX
+	NEGQ CX
+
+	// t[2:9] += m * q where m = t[2] * -q^-1 mod 2^64
+	MOVQ 64(SI), R9
+	MOVQ R10, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+	XORQ DI, DI
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, DI
+	ADCQ $0, DI
+	ADDQ CX, DI
+	ADDQ DI, R9
+	SBBQ CX, CX
+	NEGQ CX
+
+	// t[3:10] += m * q where m = t[3] * -q^-1 mod 2^64
+	MOVQ 72(SI), R10
+	MOVQ R11, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+	XORQ DI, DI
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, DI
+	ADCQ $0, DI
+	ADDQ CX, DI
+	ADDQ DI, R10
+	SBBQ CX, CX
+	NEGQ CX
+
+	// t[4:11] += m * q where m = t[4] * -q^-1 mod 2^64
+	MOVQ 80(SI), R11
+	MOVQ R12, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+	XORQ DI, DI
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, DI
+	ADCQ $0, DI
+	ADDQ CX, DI
+	ADDQ DI, R11
+	SBBQ CX, CX
+	NEGQ CX
+
+	// t[5:12] += m * q where m = t[5] * -q^-1 mod 2^64
+	MOVQ 88(SI), R12
+	MOVQ R13, DX
+	MOVQ $0x89f3fffcfffcfffd, AX
+	IMULQ AX, DX
+	XORQ DI, DI
+	MULXQ ·qFieldModulusRepr+0(SB), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, R14
+	MULXQ ·qFieldModulusRepr+8(SB), AX, BX
+	ADCXQ AX, R14
+	ADOXQ BX, R8
+	MULXQ ·qFieldModulusRepr+16(SB), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ ·qFieldModulusRepr+24(SB), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ ·qFieldModulusRepr+32(SB), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ ·qFieldModulusRepr+40(SB), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, DI
+	ADCQ $0, DI
+	ADDQ CX, DI
+	ADDQ DI, R12
+	SBBQ CX, CX
+	NEGQ CX
+
+	// subtract the modulus if the result is not less than it
+	MOVQ R14, AX
+	MOVQ R8, BX
+	MOVQ R9, CX
+	MOVQ R10, DX
+	MOVQ R11, SI
+	MOVQ R12, DI
+	SUBQ ·qFieldModulusRepr+0(SB), AX
+	SBBQ ·qFieldModulusRepr+8(SB), BX
+	SBBQ ·qFieldModulusRepr+16(SB), CX
+	SBBQ ·qFieldModulusRepr+24(SB), DX
+	SBBQ ·qFieldModulusRepr+32(SB), SI
+	SBBQ ·qFieldModulusRepr+40(SB), DI
+	CMOVQCS R14, AX
+	CMOVQCS R8, BX
+	CMOVQCS R9, CX
+	CMOVQCS R10, DX
+	CMOVQCS R11, SI
+	CMOVQCS R12, DI
+
+	MOVQ out+0(FP), R13
+	MOVQ AX, 0(R13)
+	MOVQ BX, 8(R13)
+	MOVQ CX, 16(R13)
+	MOVQ DX, 24(R13)
+	MOVQ SI, 32(R13)
+	MOVQ DI, 40(R13)
+	RET
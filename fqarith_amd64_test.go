@@ -0,0 +1,86 @@
+//go:build amd64 && !purego
+
+package bls
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func randFQRepr(t *testing.T) FQRepr {
+	f, err := RandFQ(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f.n
+}
+
+func TestFQADXKernelsMatchGeneric(t *testing.T) {
+	if !useADX {
+		t.Skip("CPU does not support BMI2 and ADX")
+	}
+
+	edge := []FQRepr{
+		{},
+		{1},
+		fqR,
+		qFieldModulusRepr,
+	}
+	edge[3].SubNoBorrow(FQRepr{1})
+
+	for i := 0; i < 10000; i++ {
+		a := randFQRepr(t)
+		b := randFQRepr(t)
+		if i < len(edge)*len(edge) {
+			a = edge[i%len(edge)]
+			b = edge[i/len(edge)]
+		}
+
+		var expected, actual FQRepr
+		fqMulGeneric(&expected, &a, &b)
+		fqMulADX(&actual, &a, &b)
+		if expected != actual {
+			t.Fatalf("fqMulADX(%s, %s) = %s, expected %s", a, b, actual, expected)
+		}
+
+		fqSquareGeneric(&expected, &a)
+		fqSquareADX(&actual, &a)
+		if expected != actual {
+			t.Fatalf("fqSquareADX(%s) = %s, expected %s", a, actual, expected)
+		}
+
+		var wide [12]uint64
+		for j := 0; j < 6; j++ {
+			var c uint64
+			for k := 0; k < 6; k++ {
+				c, wide[j+k] = mulAddAdd(a[j], b[k], wide[j+k], c)
+			}
+			wide[j+6] = c
+		}
+		wideCopy := wide
+		fqMontReduceGeneric(&expected, &wide)
+		fqMontReduceADX(&actual, &wideCopy)
+		if expected != actual {
+			t.Fatalf("fqMontReduceADX(%s * %s) = %s, expected %s", a, b, actual, expected)
+		}
+	}
+}
+
+func BenchmarkFQMulGeneric(b *testing.B) {
+	x := FQOne.Double().n
+	y := FQOne.Neg().n
+	for i := 0; i < b.N; i++ {
+		fqMulGeneric(&x, &x, &y)
+	}
+}
+
+func BenchmarkFQMulADX(b *testing.B) {
+	if !useADX {
+		b.Skip("CPU does not support BMI2 and ADX")
+	}
+	x := FQOne.Double().n
+	y := FQOne.Neg().n
+	for i := 0; i < b.N; i++ {
+		fqMulADX(&x, &x, &y)
+	}
+}
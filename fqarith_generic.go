@@ -0,0 +1,19 @@
+//go:build !amd64 || purego
+
+package bls
+
+// fqMul sets out = a * b * 2^-384 mod q.
+func fqMul(out, a, b *FQRepr) {
+	fqMulGeneric(out, a, b)
+}
+
+// fqSquare sets out = a * a * 2^-384 mod q.
+func fqSquare(out, a *FQRepr) {
+	fqSquareGeneric(out, a)
+}
+
+// fqMontReduce performs Montgomery reduction of the 768-bit product t,
+// setting out = t * 2^-384 mod q.
+func fqMontReduce(out *FQRepr, t *[12]uint64) {
+	fqMontReduceGeneric(out, t)
+}
@@ -0,0 +1,152 @@
+package bls
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// FQRepr represents a uint384. The least significant limb is first.
+type FQRepr [6]uint64
+
+// FQReprFromBig converts a non-negative big number of at most 384 bits
+// into an FQRepr.
+func FQReprFromBig(n *big.Int) (FQRepr, error) {
+	if n.Sign() < 0 || n.BitLen() > 384 {
+		return FQRepr{}, fmt.Errorf("number does not fit in 384 bits: %s", n)
+	}
+	var b [48]byte
+	n.FillBytes(b[:])
+	return FQReprFromBytes(b), nil
+}
+
+// FQReprFromBytes converts a big-endian byte array into an FQRepr.
+func FQReprFromBytes(b [48]byte) FQRepr {
+	var out FQRepr
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 8; j++ {
+			out[5-i] = out[5-i]<<8 | uint64(b[i*8+j])
+		}
+	}
+	return out
+}
+
+// Bytes returns the big-endian encoding of the number.
+func (f FQRepr) Bytes() [48]byte {
+	var out [48]byte
+	for i := 0; i < 6; i++ {
+		limb := f[5-i]
+		for j := 7; j >= 0; j-- {
+			out[i*8+j] = byte(limb)
+			limb >>= 8
+		}
+	}
+	return out
+}
+
+// ToBig converts the FQRepr to a big number.
+func (f FQRepr) ToBig() *big.Int {
+	b := f.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+func (f FQRepr) String() string {
+	return fmt.Sprintf("0x%016x%016x%016x%016x%016x%016x", f[5], f[4], f[3], f[2], f[1], f[0])
+}
+
+// IsOdd checks if the number is odd.
+func (f FQRepr) IsOdd() bool {
+	return f[0]&1 == 1
+}
+
+// IsEven checks if the number is even.
+func (f FQRepr) IsEven() bool {
+	return f[0]&1 == 0
+}
+
+// IsZero checks if the number is zero.
+func (f FQRepr) IsZero() bool {
+	return f[0]|f[1]|f[2]|f[3]|f[4]|f[5] == 0
+}
+
+// Equals checks if two numbers are equal.
+func (f FQRepr) Equals(other FQRepr) bool {
+	return f == other
+}
+
+// Cmp compares two numbers.
+func (f FQRepr) Cmp(other FQRepr) int {
+	for i := 5; i >= 0; i-- {
+		if f[i] > other[i] {
+			return 1
+		} else if f[i] < other[i] {
+			return -1
+		}
+	}
+	return 0
+}
+
+// Bit checks if the n-th bit of the number is set.
+func (f FQRepr) Bit(n uint) bool {
+	if n >= 384 {
+		return false
+	}
+	return (f[n/64]>>(n%64))&1 == 1
+}
+
+// BitLen returns the number of bits needed to represent the number.
+func (f FQRepr) BitLen() int {
+	for i := 5; i >= 0; i-- {
+		if f[i] != 0 {
+			return i*64 + bits.Len64(f[i])
+		}
+	}
+	return 0
+}
+
+// Div2 divides the number by two.
+func (f *FQRepr) Div2() {
+	var t uint64
+	for i := 5; i >= 0; i-- {
+		t2 := f[i] << 63
+		f[i] >>= 1
+		f[i] |= t
+		t = t2
+	}
+}
+
+// Mul2 multiplies the number by two.
+func (f *FQRepr) Mul2() {
+	var last uint64
+	for i := 0; i < 6; i++ {
+		tmp := f[i] >> 63
+		f[i] <<= 1
+		f[i] |= last
+		last = tmp
+	}
+}
+
+// AddNoCarry adds another number to this one and returns the carry.
+func (f *FQRepr) AddNoCarry(other FQRepr) bool {
+	var carry uint64
+	f[0], carry = bits.Add64(f[0], other[0], 0)
+	f[1], carry = bits.Add64(f[1], other[1], carry)
+	f[2], carry = bits.Add64(f[2], other[2], carry)
+	f[3], carry = bits.Add64(f[3], other[3], carry)
+	f[4], carry = bits.Add64(f[4], other[4], carry)
+	f[5], carry = bits.Add64(f[5], other[5], carry)
+	return carry != 0
+}
+
+// SubNoBorrow subtracts another number from this one and returns the
+// borrow.
+func (f *FQRepr) SubNoBorrow(other FQRepr) bool {
+	var borrow uint64
+	f[0], borrow = bits.Sub64(f[0], other[0], 0)
+	f[1], borrow = bits.Sub64(f[1], other[1], borrow)
+	f[2], borrow = bits.Sub64(f[2], other[2], borrow)
+	f[3], borrow = bits.Sub64(f[3], other[3], borrow)
+	f[4], borrow = bits.Sub64(f[4], other[4], borrow)
+	f[5], borrow = bits.Sub64(f[5], other[5], borrow)
+	return borrow != 0
+}
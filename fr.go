@@ -1,14 +1,15 @@
 package bls
 
 import (
-	"crypto/rand"
+	"errors"
 	"io"
 	"math/big"
 )
 
-// FR is an element in a field.
+// FR is an element in a field. It is stored in Montgomery form as four
+// 64-bit limbs.
 type FR struct {
-	n *big.Int
+	n FRRepr
 }
 
 // RFieldModulus is the modulus of the field.
@@ -17,163 +18,230 @@ var RFieldModulus, _ = new(big.Int).SetString("524358751751261904794477405081859
 // NewFR creates a new field element.
 func NewFR(n *big.Int) *FR {
 	outN := new(big.Int).Mod(n, RFieldModulus)
-	return &FR{n: outN}
+	r, _ := FRReprFromBig(outN)
+	return FRReprToFR(r)
+}
+
+// FRReprToFR converts a 256-bit number into a field element, reducing it
+// modulo RFieldModulus.
+func FRReprToFR(r FRRepr) *FR {
+	out := &FR{}
+	// r * R^2 < 2^256 * RFieldModulus, so one Montgomery multiplication
+	// both reduces r and moves it into Montgomery form.
+	frMul(&out.n, &r, &frR2)
+	return out
+}
+
+// FRFromBytes converts a big-endian encoded scalar into a field element.
+// It returns an error if the scalar is not less than RFieldModulus.
+func FRFromBytes(b [32]byte) (*FR, error) {
+	r := FRReprFromBytes(b)
+	if r.Cmp(rFieldModulusRepr) >= 0 {
+		return nil, errors.New("scalar is not less than the field modulus")
+	}
+	return FRReprToFR(r), nil
+}
+
+// ToRepr converts the field element out of Montgomery form.
+func (f FR) ToRepr() FRRepr {
+	var t [8]uint64
+	copy(t[:], f.n[:])
+	var out FRRepr
+	frMontReduce(&out, &t)
+	return out
+}
+
+// Bytes returns the big-endian encoding of the field element.
+func (f FR) Bytes() [32]byte {
+	return f.ToRepr().Bytes()
 }
 
 // Copy creates a copy of the field element.
 func (f FR) Copy() *FR {
-	return &FR{n: new(big.Int).Set(f.n)}
+	return &FR{n: f.n}
 }
 
 // Add adds two field elements together.
 func (f FR) Add(other *FR) *FR {
-	out := new(big.Int).Add(f.n, other.n)
-	out.Mod(out, RFieldModulus)
-	return &FR{n: out}
+	out := &FR{}
+	frAdd(&out.n, &f.n, &other.n)
+	return out
 }
 
 // AddAssign adds two field elements together.
-func (f FR) AddAssign(other *FR) {
-	f.n.Add(f.n, other.n)
-	f.n.Mod(f.n, RFieldModulus)
+func (f *FR) AddAssign(other *FR) {
+	frAdd(&f.n, &f.n, &other.n)
 }
 
 // Mul multiplies two field elements together.
 func (f FR) Mul(other *FR) *FR {
-	out := new(big.Int).Mul(f.n, other.n)
-	out.Mod(out, RFieldModulus)
-	return &FR{n: out}
+	out := &FR{}
+	frMul(&out.n, &f.n, &other.n)
+	return out
 }
 
 // MulAssign multiplies one field element by the other.
-func (f FR) MulAssign(other *FR) {
-	f.n.Mul(f.n, other.n)
-	f.n.Mod(f.n, RFieldModulus)
+func (f *FR) MulAssign(other *FR) {
+	frMul(&f.n, &f.n, &other.n)
 }
 
 // Sub subtracts one field element from the other.
 func (f FR) Sub(other *FR) *FR {
-	out := new(big.Int).Sub(f.n, other.n)
-	out.Mod(out, RFieldModulus)
-	return &FR{n: out}
+	out := &FR{}
+	frSub(&out.n, &f.n, &other.n)
+	return out
 }
 
 // SubAssign subtracts one field element from the other.
-func (f FR) SubAssign(other *FR) {
-	f.n.Sub(f.n, other.n)
-	f.n.Mod(f.n, RFieldModulus)
+func (f *FR) SubAssign(other *FR) {
+	frSub(&f.n, &f.n, &other.n)
 }
 
 // Div divides one field element by another.
 func (f FR) Div(other *FR) *FR {
-	otherInverse := &FR{n: primeFieldInv(other.n, RFieldModulus)}
+	otherInverse := other.Inverse()
+	if otherInverse == nil {
+		return FRZero.Copy()
+	}
 	return f.Mul(otherInverse)
 }
 
-// Exp exponentiates the field element to the given power.
+// Exp exponentiates the field element to the given power. The running
+// time depends only on the bit length of the exponent, rounded up to 256
+// bits, so secret exponents are safe.
 func (f FR) Exp(n *big.Int) *FR {
-	if n.Cmp(bigZero) == 0 {
-		return &FR{n: new(big.Int).Set(bigOne)}
-	} else if n.Cmp(bigOne) == 0 {
-		return f.Copy()
-	} else if new(big.Int).Mod(n, bigTwo).Cmp(bigZero) == 0 {
-		return f.Mul(&f).Exp(new(big.Int).Div(n, bigTwo))
-	} else {
-		return f.Mul(&f).Exp(new(big.Int).Div(n, bigTwo)).Mul(&f)
+	bitLen := 256
+	if n.BitLen() > bitLen {
+		bitLen = n.BitLen()
+	}
+	res := FROne.Copy()
+	var tmp FRRepr
+	for i := bitLen - 1; i >= 0; i-- {
+		frMul(&res.n, &res.n, &res.n)
+		frMul(&tmp, &res.n, &f.n)
+		frSelect(&res.n, &res.n, &tmp, uint64(n.Bit(i)))
 	}
+	return res
 }
 
-// Equals checks equality of two field elements.
+// Equals checks equality of two field elements in constant time.
 func (f FR) Equals(other *FR) bool {
-	return f.n.Cmp(other.n) == 0
+	return frEqual(&f.n, &other.n) == 1
+}
+
+// ConditionalAssign sets the field element to other if choice is 1 and
+// leaves it unchanged if choice is 0. It does not branch on choice.
+func (f *FR) ConditionalAssign(other *FR, choice int) {
+	frSelect(&f.n, &f.n, &other.n, uint64(choice))
 }
 
 // Neg gets the negative value of the field element mod RFieldModulus.
 func (f FR) Neg() *FR {
-	return NewFR(new(big.Int).Neg(f.n))
+	out := &FR{}
+	frNeg(&out.n, &f.n)
+	return out
 }
 
 func (f FR) String() string {
-	return f.n.String()
+	return f.ToBig().String()
 }
 
 // Cmp compares this field element to another.
 func (f FR) Cmp(other *FR) int {
-	return f.n.Cmp(other.n)
+	return f.ToRepr().Cmp(other.ToRepr())
 }
 
 // Double doubles the
 func (f FR) Double() *FR {
-	return NewFR(new(big.Int).Lsh(f.n, 1))
+	out := &FR{}
+	frAdd(&out.n, &f.n, &f.n)
+	return out
 }
 
 // IsZero checks if the field element is zero.
 func (f FR) IsZero() bool {
-	return f.n.Cmp(bigZero) == 0
+	return frEqual(&f.n, &FRRepr{}) == 1
 }
 
 // Square squares a field element.
 func (f FR) Square() *FR {
-	return NewFR(new(big.Int).Mul(f.n, f.n))
+	out := &FR{}
+	frMul(&out.n, &f.n, &f.n)
+	return out
 }
 
+// rTMinus1Over2 is (t - 1) / 2 where RFieldModulus - 1 = 2^32 * t.
+var rTMinus1Over2, _ = new(big.Int).SetString("39f6d3a994cebea4199cec0404d0ec02a9ded2017fff2dff7fffffff", 16)
+
+// rTwoAdicity is the largest s such that 2^s divides RFieldModulus - 1.
+const rTwoAdicity = 32
+
+// rRootOfUnity is 7^t in Montgomery form, a primitive 2^32-th root of
+// unity.
+var rRootOfUnity = FR{n: FRRepr{
+	0xb9b58d8c5f0e466a,
+	0x5b1b4c801819d7ec,
+	0x0af53ae352a31e64,
+	0x5bf3adda19e9b27b,
+}}
+
 // Sqrt calculates the square root of the field element.
 func (f FR) Sqrt() *FR {
-	// Shank's algorithm for q mod 4 = 3
-	// https://eprint.iacr.org/2012/685.pdf (page 9, algorithm 2)
-
-	a1 := f.Exp(qMinus3Over4)
-	a0 := a1.Square().Mul(&f)
+	// Constant-time variant of Tonelli-Shanks since RFieldModulus = 1
+	// mod 4. Every loop runs for a fixed number of iterations and the
+	// branches are replaced by conditional selects.
+	w := f.Exp(rTMinus1Over2)
+	v := uint64(rTwoAdicity)
+	x := f.Mul(w)
+	b := x.Mul(w)
+	z := rRootOfUnity.Copy()
+
+	for maxV := uint64(rTwoAdicity); maxV >= 1; maxV-- {
+		k := uint64(1)
+		tmp := b.Square()
+		jLessThanV := uint64(1)
+		for j := uint64(2); j < maxV; j++ {
+			tmpIsOne := frEqual(&tmp.n, &FROne.n)
+			var squared FRRepr
+			frSelect(&squared, &tmp.n, &z.n, tmpIsOne)
+			frMul(&squared, &squared, &squared)
+			frSelect(&tmp.n, &squared, &tmp.n, tmpIsOne)
+			var newZ FRRepr
+			frSelect(&newZ, &z.n, &squared, tmpIsOne)
+			jLessThanV &= 1 ^ ctEqualUint64(j, v)
+			k = j ^ (-tmpIsOne & (j ^ k))
+			frSelect(&z.n, &z.n, &newZ, jLessThanV)
+		}
+		var result FRRepr
+		frMul(&result, &x.n, &z.n)
+		frSelect(&x.n, &result, &x.n, frEqual(&b.n, &FROne.n))
+		frMul(&z.n, &z.n, &z.n)
+		frMul(&b.n, &b.n, &z.n)
+		v = k
+	}
 
-	if a0.Equals(NewFR(negativeOne)) {
+	if !x.Square().Equals(&f) {
 		return nil
 	}
-	return a1.Mul(&f)
+	return x
 }
 
+// ctEqualUint64 returns 1 if a == b and 0 otherwise.
+func ctEqualUint64(a, b uint64) uint64 {
+	d := a ^ b
+	return 1 ^ ((d | -d) >> 63)
+}
+
+// rMinus2 is RFieldModulus - 2.
+var rMinus2 = new(big.Int).Sub(RFieldModulus, bigTwo)
+
 // Inverse finds the inverse of the field element.
 func (f FR) Inverse() *FR {
 	if f.IsZero() {
 		return nil
 	}
-	u := new(big.Int).Set(f.n)
-	v := new(big.Int).Set(RFieldModulus)
-	b := NewFR(new(big.Int).Set(bigOne))
-	c := FRZero.Copy()
-
-	for u.Cmp(bigOne) != 0 && v.Cmp(bigOne) != 0 {
-		for isEven(u) {
-			u.Div(u, bigTwo)
-			if isEven(b.n) {
-				b.n.Div(b.n, bigTwo)
-			} else {
-				b.n.Add(b.n, RFieldModulus)
-				b.n.Div(b.n, bigTwo)
-			}
-		}
-
-		for isEven(v) {
-			v.Div(v, bigTwo)
-			if isEven(c.n) {
-				c.n.Div(c.n, bigTwo)
-			} else {
-				c.n.Add(c.n, RFieldModulus)
-				c.n.Div(c.n, bigTwo)
-			}
-		}
-
-		if u.Cmp(v) >= 0 {
-			u.Sub(u, v)
-			b.SubAssign(c)
-		} else {
-			v.Sub(v, u)
-			c.SubAssign(b)
-		}
-	}
-	if u.Cmp(bigOne) == 0 {
-		return b
-	}
-	return c
+	// Fermat's little theorem runs in constant time unlike a binary GCD.
+	return f.Exp(rMinus2)
 }
 
 var rMinus1Over2, _ = new(big.Int).SetString("26217937587563095239723870254092982918845276250263818911301829349969290592256", 10)
@@ -192,16 +260,24 @@ func (f *FR) Legendre() LegendreSymbol {
 
 // ToBig converts the FR element to the underlying big number.
 func (f *FR) ToBig() *big.Int {
-	return new(big.Int).Set(f.n)
+	return f.ToRepr().ToBig()
 }
 
 // RandFR generates a random FR element.
 func RandFR(reader io.Reader) (*FR, error) {
-	r, err := rand.Int(reader, RFieldModulus)
-	if err != nil {
-		return nil, err
+	var b [32]byte
+	for {
+		if _, err := io.ReadFull(reader, b[:]); err != nil {
+			return nil, err
+		}
+		// RFieldModulus is 255 bits so clearing the top bit keeps the
+		// rejection rate under 50%.
+		b[0] &= 0x7f
+		r := FRReprFromBytes(b)
+		if r.Cmp(rFieldModulusRepr) < 0 {
+			return FRReprToFR(r), nil
+		}
 	}
-	return NewFR(r), nil
 }
 
 // FRZero is the FR at 0.
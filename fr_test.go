@@ -0,0 +1,164 @@
+package bls_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+func TestFRArithmeticAgainstBig(t *testing.T) {
+	r := bls.RFieldModulus
+	for i := 0; i < 1000; i++ {
+		a, _ := rand.Int(rand.Reader, r)
+		b, _ := rand.Int(rand.Reader, r)
+		fa := bls.NewFR(a)
+		fb := bls.NewFR(b)
+
+		if fa.ToBig().Cmp(a) != 0 {
+			t.Fatal("FR does not round-trip through big.Int")
+		}
+
+		expected := new(big.Int).Add(a, b)
+		expected.Mod(expected, r)
+		if fa.Add(fb).ToBig().Cmp(expected) != 0 {
+			t.Fatal("FR Add does not match big.Int")
+		}
+
+		expected = new(big.Int).Sub(a, b)
+		expected.Mod(expected, r)
+		if fa.Sub(fb).ToBig().Cmp(expected) != 0 {
+			t.Fatal("FR Sub does not match big.Int")
+		}
+
+		expected = new(big.Int).Mul(a, b)
+		expected.Mod(expected, r)
+		if fa.Mul(fb).ToBig().Cmp(expected) != 0 {
+			t.Fatal("FR Mul does not match big.Int")
+		}
+
+		expected = new(big.Int).Neg(a)
+		expected.Mod(expected, r)
+		if fa.Neg().ToBig().Cmp(expected) != 0 {
+			t.Fatal("FR Neg does not match big.Int")
+		}
+
+		if fa.Cmp(fb) != a.Cmp(b) {
+			t.Fatal("FR Cmp does not match big.Int")
+		}
+	}
+}
+
+func TestFRInverse(t *testing.T) {
+	if bls.FRZero.Inverse() != nil {
+		t.Fatal("inverse of zero should be nil")
+	}
+	for i := 0; i < 100; i++ {
+		a, err := bls.RandFR(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := new(big.Int).ModInverse(a.ToBig(), bls.RFieldModulus)
+		if a.Inverse().ToBig().Cmp(expected) != 0 {
+			t.Fatal("FR Inverse does not match big.Int")
+		}
+	}
+}
+
+func TestFRExp(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		a, _ := rand.Int(rand.Reader, bls.RFieldModulus)
+		e, _ := rand.Int(rand.Reader, bls.RFieldModulus)
+		expected := new(big.Int).Exp(a, e, bls.RFieldModulus)
+		if bls.NewFR(a).Exp(e).ToBig().Cmp(expected) != 0 {
+			t.Fatal("FR Exp does not match big.Int")
+		}
+	}
+}
+
+func TestFRSqrt(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a, err := bls.RandFR(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a2 := a.Square()
+		s := a2.Sqrt()
+		if s == nil || !s.Square().Equals(a2) {
+			t.Fatal("sqrt(a^2)^2 != a^2")
+		}
+
+		// 7 generates the multiplicative group so it is a non-residue
+		nonResidue := a2.Mul(bls.NewFR(big.NewInt(7)))
+		if !a.IsZero() && nonResidue.Sqrt() != nil {
+			t.Fatal("found a square root of a non-residue")
+		}
+	}
+}
+
+func TestFRBytes(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a, err := bls.RandFR(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := bls.FRFromBytes(a.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equals(b) {
+			t.Fatal("FR does not round-trip through bytes")
+		}
+	}
+
+	var tooBig [32]byte
+	for i := range tooBig {
+		tooBig[i] = 0xff
+	}
+	if _, err := bls.FRFromBytes(tooBig); err == nil {
+		t.Fatal("expected non-canonical scalar to be rejected")
+	}
+}
+
+func BenchmarkFRMul(b *testing.B) {
+	type mulData struct {
+		f1 *bls.FR
+		f2 *bls.FR
+	}
+
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]mulData{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		f1, _ := bls.RandFR(r)
+		f2, _ := bls.RandFR(r)
+		inData[i] = mulData{
+			f1: f1,
+			f2: f2,
+		}
+	}
+
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		inData[count].f1.Mul(inData[count].f2)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
+
+func BenchmarkFRInverse(b *testing.B) {
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]*bls.FR{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		inData[i], _ = bls.RandFR(r)
+	}
+
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		inData[count].Inverse()
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
@@ -0,0 +1,124 @@
+package bls
+
+import "math/bits"
+
+// The scalar field arithmetic below never branches on or indexes memory
+// by the value of its operands, so it is safe to use on secret scalars.
+
+// rFieldModulusRepr is RFieldModulus as limbs.
+var rFieldModulusRepr = FRRepr{
+	0xffffffff00000001,
+	0x53bda402fffe5bfe,
+	0x3339d80809a1d805,
+	0x73eda753299d7d48,
+}
+
+// frR is 2^256 mod r, the Montgomery form of one.
+var frR = FRRepr{
+	0x00000001fffffffe,
+	0x5884b7fa00034802,
+	0x998c4fefecbc4ff5,
+	0x1824b159acc5056f,
+}
+
+// frR2 is 2^512 mod r, used to convert into Montgomery form.
+var frR2 = FRRepr{
+	0xc999e990f3f29c6d,
+	0x2b6cedcb87925c23,
+	0x05d314967254398f,
+	0x0748d9d99f59ff11,
+}
+
+// frInv is -r^-1 mod 2^64.
+const frInv = 0xfffffffeffffffff
+
+// frSelect sets out to a if choice is 0 and to b if choice is 1.
+func frSelect(out, a, b *FRRepr, choice uint64) {
+	mask := -choice
+	out[0] = a[0] ^ (mask & (a[0] ^ b[0]))
+	out[1] = a[1] ^ (mask & (a[1] ^ b[1]))
+	out[2] = a[2] ^ (mask & (a[2] ^ b[2]))
+	out[3] = a[3] ^ (mask & (a[3] ^ b[3]))
+}
+
+// frEqual returns 1 if a == b and 0 otherwise.
+func frEqual(a, b *FRRepr) uint64 {
+	d := (a[0] ^ b[0]) | (a[1] ^ b[1]) | (a[2] ^ b[2]) | (a[3] ^ b[3])
+	// d | -d has its top bit set iff d != 0
+	return 1 ^ ((d | -d) >> 63)
+}
+
+// frReduce subtracts the modulus from a if a >= r given the carry out of
+// the computation that produced a.
+func frReduce(out *FRRepr, a *FRRepr, carry uint64) {
+	var t FRRepr
+	var borrow uint64
+	t[0], borrow = bits.Sub64(a[0], rFieldModulusRepr[0], 0)
+	t[1], borrow = bits.Sub64(a[1], rFieldModulusRepr[1], borrow)
+	t[2], borrow = bits.Sub64(a[2], rFieldModulusRepr[2], borrow)
+	t[3], borrow = bits.Sub64(a[3], rFieldModulusRepr[3], borrow)
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	frSelect(out, &t, a, borrow)
+}
+
+// frAdd sets out = a + b mod r.
+func frAdd(out, a, b *FRRepr) {
+	var t FRRepr
+	var carry uint64
+	t[0], carry = bits.Add64(a[0], b[0], 0)
+	t[1], carry = bits.Add64(a[1], b[1], carry)
+	t[2], carry = bits.Add64(a[2], b[2], carry)
+	t[3], carry = bits.Add64(a[3], b[3], carry)
+	frReduce(out, &t, carry)
+}
+
+// frSub sets out = a - b mod r.
+func frSub(out, a, b *FRRepr) {
+	var t FRRepr
+	var borrow uint64
+	t[0], borrow = bits.Sub64(a[0], b[0], 0)
+	t[1], borrow = bits.Sub64(a[1], b[1], borrow)
+	t[2], borrow = bits.Sub64(a[2], b[2], borrow)
+	t[3], borrow = bits.Sub64(a[3], b[3], borrow)
+	mask := -borrow
+	var carry uint64
+	out[0], carry = bits.Add64(t[0], rFieldModulusRepr[0]&mask, 0)
+	out[1], carry = bits.Add64(t[1], rFieldModulusRepr[1]&mask, carry)
+	out[2], carry = bits.Add64(t[2], rFieldModulusRepr[2]&mask, carry)
+	out[3], _ = bits.Add64(t[3], rFieldModulusRepr[3]&mask, carry)
+}
+
+// frNeg sets out = -a mod r.
+func frNeg(out, a *FRRepr) {
+	frSub(out, &FRRepr{}, a)
+}
+
+// frMul sets out = a * b * 2^-256 mod r.
+func frMul(out, a, b *FRRepr) {
+	var t [8]uint64
+	for i := 0; i < 4; i++ {
+		var c uint64
+		for j := 0; j < 4; j++ {
+			c, t[i+j] = mulAddAdd(a[i], b[j], t[i+j], c)
+		}
+		t[i+4] = c
+	}
+	frMontReduce(out, &t)
+}
+
+// frMontReduce performs Montgomery reduction of the 512-bit product t,
+// setting out = t * 2^-256 mod r.
+func frMontReduce(out *FRRepr, t *[8]uint64) {
+	var carry2 uint64
+	for i := 0; i < 4; i++ {
+		m := t[i] * frInv
+		var c uint64
+		for j := 0; j < 4; j++ {
+			c, t[i+j] = mulAddAdd(m, rFieldModulusRepr[j], t[i+j], c)
+		}
+		t[i+4], carry2 = bits.Add64(t[i+4], c, carry2)
+	}
+	var res FRRepr
+	copy(res[:], t[4:])
+	frReduce(out, &res, carry2)
+}
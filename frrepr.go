@@ -0,0 +1,148 @@
+package bls
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// FRRepr represents a uint256. The least significant limb is first.
+type FRRepr [4]uint64
+
+// FRReprFromBig converts a non-negative big number of at most 256 bits
+// into an FRRepr.
+func FRReprFromBig(n *big.Int) (FRRepr, error) {
+	if n.Sign() < 0 || n.BitLen() > 256 {
+		return FRRepr{}, fmt.Errorf("number does not fit in 256 bits: %s", n)
+	}
+	var b [32]byte
+	n.FillBytes(b[:])
+	return FRReprFromBytes(b), nil
+}
+
+// FRReprFromBytes converts a big-endian byte array into an FRRepr.
+func FRReprFromBytes(b [32]byte) FRRepr {
+	var out FRRepr
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			out[3-i] = out[3-i]<<8 | uint64(b[i*8+j])
+		}
+	}
+	return out
+}
+
+// Bytes returns the big-endian encoding of the number.
+func (f FRRepr) Bytes() [32]byte {
+	var out [32]byte
+	for i := 0; i < 4; i++ {
+		limb := f[3-i]
+		for j := 7; j >= 0; j-- {
+			out[i*8+j] = byte(limb)
+			limb >>= 8
+		}
+	}
+	return out
+}
+
+// ToBig converts the FRRepr to a big number.
+func (f FRRepr) ToBig() *big.Int {
+	b := f.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+func (f FRRepr) String() string {
+	return fmt.Sprintf("0x%016x%016x%016x%016x", f[3], f[2], f[1], f[0])
+}
+
+// IsOdd checks if the number is odd.
+func (f FRRepr) IsOdd() bool {
+	return f[0]&1 == 1
+}
+
+// IsEven checks if the number is even.
+func (f FRRepr) IsEven() bool {
+	return f[0]&1 == 0
+}
+
+// IsZero checks if the number is zero.
+func (f FRRepr) IsZero() bool {
+	return f[0]|f[1]|f[2]|f[3] == 0
+}
+
+// Equals checks if two numbers are equal.
+func (f FRRepr) Equals(other FRRepr) bool {
+	return f == other
+}
+
+// Cmp compares two numbers.
+func (f FRRepr) Cmp(other FRRepr) int {
+	for i := 3; i >= 0; i-- {
+		if f[i] > other[i] {
+			return 1
+		} else if f[i] < other[i] {
+			return -1
+		}
+	}
+	return 0
+}
+
+// Bit checks if the n-th bit of the number is set.
+func (f FRRepr) Bit(n uint) bool {
+	if n >= 256 {
+		return false
+	}
+	return (f[n/64]>>(n%64))&1 == 1
+}
+
+// BitLen returns the number of bits needed to represent the number.
+func (f FRRepr) BitLen() int {
+	for i := 3; i >= 0; i-- {
+		if f[i] != 0 {
+			return i*64 + bits.Len64(f[i])
+		}
+	}
+	return 0
+}
+
+// Div2 divides the number by two.
+func (f *FRRepr) Div2() {
+	var t uint64
+	for i := 3; i >= 0; i-- {
+		t2 := f[i] << 63
+		f[i] >>= 1
+		f[i] |= t
+		t = t2
+	}
+}
+
+// Mul2 multiplies the number by two.
+func (f *FRRepr) Mul2() {
+	var last uint64
+	for i := 0; i < 4; i++ {
+		tmp := f[i] >> 63
+		f[i] <<= 1
+		f[i] |= last
+		last = tmp
+	}
+}
+
+// AddNoCarry adds another number to this one and returns the carry.
+func (f *FRRepr) AddNoCarry(other FRRepr) bool {
+	var carry uint64
+	f[0], carry = bits.Add64(f[0], other[0], 0)
+	f[1], carry = bits.Add64(f[1], other[1], carry)
+	f[2], carry = bits.Add64(f[2], other[2], carry)
+	f[3], carry = bits.Add64(f[3], other[3], carry)
+	return carry != 0
+}
+
+// SubNoBorrow subtracts another number from this one and returns the
+// borrow.
+func (f *FRRepr) SubNoBorrow(other FRRepr) bool {
+	var borrow uint64
+	f[0], borrow = bits.Sub64(f[0], other[0], 0)
+	f[1], borrow = bits.Sub64(f[1], other[1], borrow)
+	f[2], borrow = bits.Sub64(f[2], other[2], borrow)
+	f[3], borrow = bits.Sub64(f[3], other[3], borrow)
+	return borrow != 0
+}
@@ -196,7 +196,7 @@ func CompressG1(affine *G1Affine) *big.Int {
 	if affine.IsZero() {
 		res[0] |= 1 << 6
 	} else {
-		out0 := affine.x.n.Bytes()
+		out0 := affine.x.ToBig().Bytes()
 
 		copy(res[48-len(out0):], out0)
 
@@ -469,7 +469,8 @@ func (g G1Projective) AddAffine(other *G1Affine) *G1Projective {
 	return NewG1Projective(newX, newY, newZ)
 }
 
-// Mul performs a EC multiply operation on the point.
+// Mul performs a EC multiply operation on the point. The running time
+// depends on the scalar, so use MulFR for secret scalars.
 func (g G1Projective) Mul(b *big.Int) *G1Projective {
 	bs := b.Bytes()
 	res := G1ProjectiveZero.Copy()
@@ -485,6 +486,173 @@ func (g G1Projective) Mul(b *big.Int) *G1Projective {
 	return res
 }
 
+// MulFR performs a EC multiply operation on the point by a scalar. Unlike
+// Mul, it runs in constant time so it is safe to use with secret scalars.
+func (g G1Projective) MulFR(s *FR) *G1Projective {
+	// fixed 4-bit windows with a constant-time table lookup
+	var table [16]g1Homogeneous
+	table[0] = g1HomogeneousZero
+	table[1] = g.toHomogeneous()
+	for i := 2; i < len(table); i++ {
+		table[i] = table[i-1]
+		table[i].add(&table[1])
+	}
+
+	k := s.ToRepr()
+	acc := g1HomogeneousZero
+	for i := 63; i >= 0; i-- {
+		acc.double()
+		acc.double()
+		acc.double()
+		acc.double()
+
+		window := (k[i/16] >> (uint(i%16) * 4)) & 0xf
+		sel := g1HomogeneousZero
+		for j := range table {
+			sel.conditionalAssign(&table[j], int(ctEqualUint64(uint64(j), window)))
+		}
+		acc.add(&sel)
+	}
+	return acc.toProjective()
+}
+
+// g1Homogeneous is a point on the G1 curve in homogeneous projective
+// coordinates, where x = X/Z and y = Y/Z. Unlike the Jacobian coordinates
+// of G1Projective, these have complete addition formulas with no special
+// cases for doubling or the point at infinity.
+type g1Homogeneous struct {
+	x FQ
+	y FQ
+	z FQ
+}
+
+var g1HomogeneousZero = g1Homogeneous{x: *FQZero, y: *FQOne, z: *FQZero}
+
+// g1B3 is 3 * BCoeff.
+var g1B3 = NewFQ(big.NewInt(12))
+
+// toHomogeneous converts (X, Y, Z) to (XZ, Y, Z^3).
+func (g G1Projective) toHomogeneous() g1Homogeneous {
+	out := g1Homogeneous{x: *g.x, y: *g.y, z: *g.z}
+	out.x.MulAssign(g.z)
+	out.z.SquareAssign()
+	out.z.MulAssign(g.z)
+	return out
+}
+
+// toProjective converts (X, Y, Z) to (XZ, YZ^2, Z).
+func (p *g1Homogeneous) toProjective() *G1Projective {
+	x := p.x.Mul(&p.z)
+	y := p.z.Square()
+	y.MulAssign(&p.y)
+	y.ConditionalAssign(FQOne, int(fqEqual(&p.z.n, &FQRepr{})))
+	return NewG1Projective(x, y, p.z.Copy())
+}
+
+// conditionalAssign sets p to q if choice is 1 without branching.
+func (p *g1Homogeneous) conditionalAssign(q *g1Homogeneous, choice int) {
+	p.x.ConditionalAssign(&q.x, choice)
+	p.y.ConditionalAssign(&q.y, choice)
+	p.z.ConditionalAssign(&q.z, choice)
+}
+
+// add sets p = p + q using the complete addition formula for a = 0
+// curves (algorithm 7 of https://eprint.iacr.org/2015/1060.pdf).
+func (p *g1Homogeneous) add(q *g1Homogeneous) {
+	t0 := p.x
+	t0.MulAssign(&q.x)
+	t1 := p.y
+	t1.MulAssign(&q.y)
+	t2 := p.z
+	t2.MulAssign(&q.z)
+	t3 := p.x
+	t3.AddAssign(&p.y)
+	t4 := q.x
+	t4.AddAssign(&q.y)
+	t3.MulAssign(&t4)
+	t4 = t0
+	t4.AddAssign(&t1)
+	t3.SubAssign(&t4)
+	t4 = p.y
+	t4.AddAssign(&p.z)
+	x3 := q.y
+	x3.AddAssign(&q.z)
+	t4.MulAssign(&x3)
+	x3 = t1
+	x3.AddAssign(&t2)
+	t4.SubAssign(&x3)
+	x3 = p.x
+	x3.AddAssign(&p.z)
+	y3 := q.x
+	y3.AddAssign(&q.z)
+	x3.MulAssign(&y3)
+	y3 = t0
+	y3.AddAssign(&t2)
+	tmp := x3
+	tmp.SubAssign(&y3)
+	y3 = tmp
+	x3 = t0
+	x3.DoubleAssign()
+	t0.AddAssign(&x3)
+	t2.MulAssign(g1B3)
+	z3 := t1
+	z3.AddAssign(&t2)
+	t1.SubAssign(&t2)
+	y3.MulAssign(g1B3)
+	x3 = t4
+	x3.MulAssign(&y3)
+	t2 = t3
+	t2.MulAssign(&t1)
+	t2.SubAssign(&x3)
+	x3 = t2
+	y3.MulAssign(&t0)
+	t1.MulAssign(&z3)
+	y3.AddAssign(&t1)
+	t0.MulAssign(&t3)
+	z3.MulAssign(&t4)
+	z3.AddAssign(&t0)
+
+	p.x = x3
+	p.y = y3
+	p.z = z3
+}
+
+// double sets p = 2p using the complete doubling formula for a = 0
+// curves (algorithm 9 of https://eprint.iacr.org/2015/1060.pdf).
+func (p *g1Homogeneous) double() {
+	t0 := p.y
+	t0.SquareAssign()
+	z3 := t0
+	z3.DoubleAssign()
+	z3.DoubleAssign()
+	z3.DoubleAssign()
+	t1 := p.y
+	t1.MulAssign(&p.z)
+	t2 := p.z
+	t2.SquareAssign()
+	t2.MulAssign(g1B3)
+	x3 := t2
+	x3.MulAssign(&z3)
+	y3 := t0
+	y3.AddAssign(&t2)
+	z3.MulAssign(&t1)
+	t1 = t2
+	t1.DoubleAssign()
+	t2.AddAssign(&t1)
+	t0.SubAssign(&t2)
+	y3.MulAssign(&t0)
+	y3.AddAssign(&x3)
+	t1 = p.x
+	t1.MulAssign(&p.y)
+	x3 = t0
+	x3.MulAssign(&t1)
+	x3.DoubleAssign()
+
+	p.x = x3
+	p.y = y3
+	p.z = z3
+}
+
 // RandG1 generates a random G1 element.
 func RandG1(r io.Reader) (*G1Projective, error) {
 	for {
@@ -555,7 +723,9 @@ func SWEncodeG1(t *FQ) *G1Affine {
 	return GetG1PointFromX(x3, parity)
 }
 
-// HashG1 converts a message to a point on the G2 curve.
+// HashG1 converts a message to a point on the G1 curve. It predates RFC
+// 9380 and its output matches no other implementation, so new code should
+// use HashToG1.
 func HashG1(msg []byte, domain uint64) *G1Projective {
 	domainBytes := [8]byte{}
 	binary.BigEndian.PutUint64(domainBytes[:], domain)
@@ -160,3 +160,53 @@ func BenchmarkG1AddAssignMixed(b *testing.B) {
 		count = (count + 1) % g1MulAssignSamples
 	}
 }
+
+func TestG1MulFR(t *testing.T) {
+	r := NewXORShift(2)
+	for i := 0; i < 20; i++ {
+		g, _ := bls.RandG1(r)
+		k, _ := bls.RandFR(r)
+		if !g.MulFR(k).Equal(g.Mul(k.ToBig())) {
+			t.Fatal("constant-time multiplication does not match double-and-add")
+		}
+	}
+
+	if !bls.G1ProjectiveOne.MulFR(bls.FRZero).IsZero() {
+		t.Fatal("multiplying by zero should give the point at infinity")
+	}
+	if !bls.G1ProjectiveZero.MulFR(bls.FROne).IsZero() {
+		t.Fatal("multiplying infinity should give the point at infinity")
+	}
+	if !bls.G1ProjectiveOne.MulFR(bls.FROne).Equal(bls.G1ProjectiveOne) {
+		t.Fatal("multiplying by one should give the same point")
+	}
+	minusOne := bls.FRZero.Sub(bls.FROne)
+	if !bls.G1ProjectiveOne.MulFR(minusOne).Add(bls.G1ProjectiveOne).IsZero() {
+		t.Fatal("multiplying by -1 should give the negated point")
+	}
+}
+
+func BenchmarkG1MulFR(b *testing.B) {
+	type mulData struct {
+		g *bls.G1Projective
+		f *bls.FR
+	}
+
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]mulData{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		g, _ := bls.RandG1(r)
+		randFR, _ := bls.RandFR(r)
+		inData[i] = mulData{
+			g: g,
+			f: randFR,
+		}
+	}
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		inData[count].g.MulFR(inData[count].f)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
@@ -197,8 +197,8 @@ func CompressG2(affine *G2Affine) *big.Int {
 	if affine.IsZero() {
 		res[0] |= 1 << 6
 	} else {
-		out0 := new(big.Int).Set(affine.x.c0.n).Bytes()
-		out1 := new(big.Int).Set(affine.x.c1.n).Bytes()
+		out0 := affine.x.c0.ToBig().Bytes()
+		out1 := affine.x.c1.ToBig().Bytes()
 		copy(res[48-len(out0):48], out0)
 		copy(res[96-len(out1):], out1)
 
@@ -477,7 +477,8 @@ func (g G2Projective) AddAffine(other *G2Affine) *G2Projective {
 	return NewG2Projective(newX, newY, newZ)
 }
 
-// Mul performs a EC multiply operation on the point.
+// Mul performs a EC multiply operation on the point. The running time
+// depends on the scalar, so use MulFR for secret scalars.
 func (g G2Projective) Mul(b *big.Int) *G2Projective {
 	bs := b.Bytes()
 	res := G2ProjectiveZero.Copy()
@@ -493,11 +494,156 @@ func (g G2Projective) Mul(b *big.Int) *G2Projective {
 	return res
 }
 
+// MulFR performs a EC multiply operation on the point by a scalar. Unlike
+// Mul, it runs in constant time so it is safe to use with secret scalars.
+func (g G2Projective) MulFR(s *FR) *G2Projective {
+	// fixed 4-bit windows with a constant-time table lookup
+	var table [16]g2Homogeneous
+	table[0] = newG2HomogeneousZero()
+	table[1] = g.toHomogeneous()
+	for i := 2; i < len(table); i++ {
+		table[i] = table[i-1]
+		table[i].add(&table[1])
+	}
+
+	k := s.ToRepr()
+	acc := newG2HomogeneousZero()
+	for i := 63; i >= 0; i-- {
+		acc.double()
+		acc.double()
+		acc.double()
+		acc.double()
+
+		window := (k[i/16] >> (uint(i%16) * 4)) & 0xf
+		sel := newG2HomogeneousZero()
+		for j := range table {
+			sel.conditionalAssign(&table[j], int(ctEqualUint64(uint64(j), window)))
+		}
+		acc.add(&sel)
+	}
+	return acc.toProjective()
+}
+
+// g2Homogeneous is a point on the G2 curve in homogeneous projective
+// coordinates, where x = X/Z and y = Y/Z. Unlike the Jacobian coordinates
+// of G2Projective, these have complete addition formulas with no special
+// cases for doubling or the point at infinity.
+type g2Homogeneous struct {
+	x *FQ2
+	y *FQ2
+	z *FQ2
+}
+
+func newG2HomogeneousZero() g2Homogeneous {
+	return g2Homogeneous{x: FQ2Zero.Copy(), y: FQ2One.Copy(), z: FQ2Zero.Copy()}
+}
+
+// g2B3 is 3 * BCoeffFQ2.
+var g2B3 = NewFQ2(NewFQ(big.NewInt(12)), NewFQ(big.NewInt(12)))
+
+// toHomogeneous converts (X, Y, Z) to (XZ, Y, Z^3).
+func (g G2Projective) toHomogeneous() g2Homogeneous {
+	return g2Homogeneous{
+		x: g.x.Mul(g.z),
+		y: g.y.Copy(),
+		z: g.z.Square().Mul(g.z),
+	}
+}
+
+// toProjective converts (X, Y, Z) to (XZ, YZ^2, Z).
+func (p *g2Homogeneous) toProjective() *G2Projective {
+	x := p.x.Mul(p.z)
+	y := p.z.Square()
+	y.MulAssign(p.y)
+	isZero := fqEqual(&p.z.c0.n, &FQRepr{}) & fqEqual(&p.z.c1.n, &FQRepr{})
+	y.ConditionalAssign(FQ2One, int(isZero))
+	return NewG2Projective(x, y, p.z.Copy())
+}
+
+// conditionalAssign sets p to q if choice is 1 without branching.
+func (p *g2Homogeneous) conditionalAssign(q *g2Homogeneous, choice int) {
+	p.x.ConditionalAssign(q.x, choice)
+	p.y.ConditionalAssign(q.y, choice)
+	p.z.ConditionalAssign(q.z, choice)
+}
+
+// add sets p = p + q using the complete addition formula for a = 0
+// curves (algorithm 7 of https://eprint.iacr.org/2015/1060.pdf).
+func (p *g2Homogeneous) add(q *g2Homogeneous) {
+	t0 := p.x.Mul(q.x)
+	t1 := p.y.Mul(q.y)
+	t2 := p.z.Mul(q.z)
+	t3 := p.x.Add(p.y)
+	t4 := q.x.Add(q.y)
+	t3.MulAssign(t4)
+	t4 = t0.Add(t1)
+	t3.SubAssign(t4)
+	t4 = p.y.Add(p.z)
+	x3 := q.y.Add(q.z)
+	t4.MulAssign(x3)
+	x3 = t1.Add(t2)
+	t4.SubAssign(x3)
+	x3 = p.x.Add(p.z)
+	y3 := q.x.Add(q.z)
+	x3.MulAssign(y3)
+	y3 = t0.Add(t2)
+	y3 = x3.Sub(y3)
+	x3 = t0.Double()
+	t0.AddAssign(x3)
+	t2.MulAssign(g2B3)
+	z3 := t1.Add(t2)
+	t1.SubAssign(t2)
+	y3.MulAssign(g2B3)
+	x3 = t4.Mul(y3)
+	t2 = t3.Mul(t1)
+	x3 = t2.Sub(x3)
+	y3.MulAssign(t0)
+	t1.MulAssign(z3)
+	y3.AddAssign(t1)
+	t0.MulAssign(t3)
+	z3.MulAssign(t4)
+	z3.AddAssign(t0)
+
+	p.x = x3
+	p.y = y3
+	p.z = z3
+}
+
+// double sets p = 2p using the complete doubling formula for a = 0
+// curves (algorithm 9 of https://eprint.iacr.org/2015/1060.pdf).
+func (p *g2Homogeneous) double() {
+	t0 := p.y.Square()
+	z3 := t0.Double()
+	z3.DoubleAssign()
+	z3.DoubleAssign()
+	t1 := p.y.Mul(p.z)
+	t2 := p.z.Square()
+	t2.MulAssign(g2B3)
+	x3 := t2.Mul(z3)
+	y3 := t0.Add(t2)
+	z3.MulAssign(t1)
+	t1 = t2.Double()
+	t2.AddAssign(t1)
+	t0.SubAssign(t2)
+	y3.MulAssign(t0)
+	y3.AddAssign(x3)
+	t1 = p.x.Mul(p.y)
+	x3 = t0.Mul(t1)
+	x3.DoubleAssign()
+
+	p.x = x3
+	p.y = y3
+	p.z = z3
+}
+
 var blsX, _ = new(big.Int).SetString("d201000000010000", 16)
 
 const blsIsNegative = true
 
-// G2Prepared is a prepared G2 point multiplication by blsX.
+// G2Prepared is a prepared G2 point multiplication by blsX. It holds the
+// line coefficients of the Miller loop and is never modified after
+// G2AffineToPrepared returns, so it can be cached and shared between
+// goroutines.
 type G2Prepared struct {
 	coeffs   [][3]*FQ2
 	infinity bool
@@ -699,7 +845,9 @@ func SWEncodeG2(t *FQ2) *G2Affine {
 	return GetG2PointFromX(x3, parity)
 }
 
-// HashG2 converts a message to a point on the G2 curve.
+// HashG2 converts a message to a point on the G2 curve. It predates RFC
+// 9380 and its output matches no other implementation, so new code should
+// use HashToG2.
 func HashG2(msg []byte, domain uint64) *G2Projective {
 	domainBytes := [8]byte{}
 	binary.BigEndian.PutUint64(domainBytes[:], domain)
@@ -719,10 +867,8 @@ func HashG2(msg []byte, domain uint64) *G2Projective {
 	xCoordinate := NewFQ2(xRe, xIm)
 
 	for {
-		yCoordinateSquared := xCoordinate.Copy()
-		yCoordinateSquared.Square()
-		yCoordinateSquared.Mul(yCoordinateSquared)
-
+		yCoordinateSquared := xCoordinate.Square()
+		yCoordinateSquared.MulAssign(xCoordinate)
 		yCoordinateSquared.AddAssign(BCoeffFQ2)
 
 		yCoordinate := yCoordinateSquared.Sqrt()
@@ -1,6 +1,7 @@
 package bls_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/phoreproject/bls"
@@ -89,3 +90,61 @@ func BenchmarkG2AddAssignMixed(b *testing.B) {
 		count = (count + 1) % g1MulAssignSamples
 	}
 }
+
+func TestG2MulFR(t *testing.T) {
+	r := NewXORShift(2)
+	for i := 0; i < 10; i++ {
+		g, _ := bls.RandG2(r)
+		k, _ := bls.RandFR(r)
+		if !g.MulFR(k).Equal(g.Mul(k.ToBig())) {
+			t.Fatal("constant-time multiplication does not match double-and-add")
+		}
+	}
+
+	if !bls.G2ProjectiveOne.MulFR(bls.FRZero).IsZero() {
+		t.Fatal("multiplying by zero should give the point at infinity")
+	}
+	if !bls.G2ProjectiveZero.MulFR(bls.FROne).IsZero() {
+		t.Fatal("multiplying infinity should give the point at infinity")
+	}
+	if !bls.G2ProjectiveOne.MulFR(bls.FROne).Equal(bls.G2ProjectiveOne) {
+		t.Fatal("multiplying by one should give the same point")
+	}
+}
+
+func BenchmarkG2MulFR(b *testing.B) {
+	type mulData struct {
+		g *bls.G2Projective
+		f *bls.FR
+	}
+
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]mulData{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		g, _ := bls.RandG2(r)
+		randFR, _ := bls.RandFR(r)
+		inData[i] = mulData{
+			g: g,
+			f: randFR,
+		}
+	}
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		inData[count].g.MulFR(inData[count].f)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
+
+func TestHashG2OnCurve(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		p := bls.HashG2([]byte(fmt.Sprintf("message %d", i)), 0).ToAffine()
+		if !p.IsOnCurve() {
+			t.Fatal("hashed point is not on the curve")
+		}
+		if !p.IsInCorrectSubgroupAssumingOnCurve() {
+			t.Fatal("hashed point is not in G2")
+		}
+	}
+}
@@ -1,7 +1,11 @@
 module github.com/phoreproject/bls
 
+go 1.18
+
 require (
 	github.com/minio/blake2b-simd v0.0.0-20160723061019-3f5f724cb5b1
 	golang.org/x/crypto v0.0.0-20181127143415-eb0de9b17e85
-	golang.org/x/sys v0.0.0-20181122145206-62eef0e2fa9b // indirect
+	golang.org/x/text v0.16.0
 )
+
+require golang.org/x/sys v0.0.0-20181122145206-62eef0e2fa9b
@@ -0,0 +1,249 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GT is an element of the order r subgroup of FQ12 that pairings map
+// into. The group operation is FQ12 multiplication.
+type GT struct {
+	f *FQ12
+}
+
+// GTOne returns the identity element of GT.
+func GTOne() *GT {
+	return &GT{f: FQ12One.Copy()}
+}
+
+// NewGT converts an FQ12 element into a GT element. It returns an error if
+// the element is not in the order r subgroup.
+func NewGT(f *FQ12) (*GT, error) {
+	return newGTChecked(f.Copy())
+}
+
+// PairingGT performs a pairing and returns the result as a GT element.
+func PairingGT(p *G1Projective, q *G2Projective) *GT {
+	return &GT{f: Pairing(p, q)}
+}
+
+// FQ12 returns the underlying FQ12 element.
+func (g GT) FQ12() *FQ12 {
+	return g.f.Copy()
+}
+
+func (g GT) String() string {
+	return fmt.Sprintf("GT(%s)", g.f)
+}
+
+// Copy returns a copy of the element.
+func (g GT) Copy() *GT {
+	return &GT{f: g.f.Copy()}
+}
+
+// Mul multiplies two GT elements.
+func (g GT) Mul(other *GT) *GT {
+	return &GT{f: g.f.Mul(other.f)}
+}
+
+// Inverse finds the inverse of the element. Elements of GT have norm one
+// over FQ6, so the inverse is the conjugate.
+func (g GT) Inverse() *GT {
+	return &GT{f: g.f.Copy().Conjugate()}
+}
+
+// Equal checks if two GT elements are equal.
+func (g GT) Equal(other *GT) bool {
+	return g.f.Equals(other.f)
+}
+
+// IsOne checks if the element is the identity.
+func (g GT) IsOne() bool {
+	return g.f.Equals(FQ12One)
+}
+
+// Exp raises the element to a scalar power. It uses fixed 4-bit windows
+// and a constant-time table lookup, so the running time does not depend
+// on the scalar.
+func (g GT) Exp(s *FR) *GT {
+	var table [16]*FQ12
+	table[0] = FQ12One.Copy()
+	table[1] = g.f.Copy()
+	for i := 2; i < len(table); i++ {
+		table[i] = table[i-1].Mul(g.f)
+	}
+
+	k := s.ToRepr()
+	acc := FQ12One.Copy()
+	for i := 63; i >= 0; i-- {
+		acc = acc.CyclotomicSquare()
+		acc = acc.CyclotomicSquare()
+		acc = acc.CyclotomicSquare()
+		acc = acc.CyclotomicSquare()
+
+		window := (k[i/16] >> (uint(i%16) * 4)) & 0xf
+		sel := FQ12One.Copy()
+		for j := range table {
+			sel.ConditionalAssign(table[j], int(ctEqualUint64(uint64(j), window)))
+		}
+		acc.MulAssign(sel)
+	}
+	return &GT{f: acc}
+}
+
+// IsInCorrectSubgroup checks if the element is in the order r subgroup of
+// FQ12. It first checks that the element is in the cyclotomic subgroup,
+// f^(q^4 - q^2 + 1) = 1, and then uses that f^q = f^x exactly for the
+// elements of order r in it (Scott, https://eprint.iacr.org/2021/1130).
+func (g GT) IsInCorrectSubgroup() bool {
+	f := g.f
+	if f.Equals(FQ12Zero) {
+		return false
+	}
+
+	// f^(q^4) * f == f^(q^2)
+	fq2 := f.FrobeniusMap(2)
+	if !fq2.FrobeniusMap(2).Mul(f).Equals(fq2) {
+		return false
+	}
+
+	fx := f.cyclotomicExpCompressed(blsX)
+	if blsIsNegative {
+		fx.ConjugateAssign()
+	}
+	return f.FrobeniusMap(1).Equals(fx)
+}
+
+// GTSize is the size of a serialized GT element.
+const GTSize = 576
+
+// GTCompressedT2Size is the size of a GT element compressed with
+// CompressT2.
+const GTCompressedT2Size = 288
+
+// GTCompressedSize is the size of a GT element compressed with Compress.
+const GTCompressedSize = 192
+
+// appendFQs appends the 48 byte big-endian encoding of each element.
+func appendFQs(out []byte, fs ...*FQ) []byte {
+	for _, f := range fs {
+		b := f.Bytes()
+		out = append(out, b[:]...)
+	}
+	return out
+}
+
+// readFQs decodes len(b) / 48 canonical FQ elements.
+func readFQs(b []byte) ([]*FQ, error) {
+	out := make([]*FQ, len(b)/48)
+	for i := range out {
+		var buf [48]byte
+		copy(buf[:], b[i*48:])
+		f, err := FQFromBytes(buf)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = f
+	}
+	return out, nil
+}
+
+// newGTChecked wraps an FQ12 element that was decoded from untrusted input.
+func newGTChecked(f *FQ12) (*GT, error) {
+	g := &GT{f: f}
+	if !g.IsInCorrectSubgroup() {
+		return nil, errors.New("element is not in the correct subgroup")
+	}
+	return g, nil
+}
+
+// Serialize serializes the element as its twelve FQ coefficients, each as
+// 48 big-endian bytes, in the order c0.c0.c0, c0.c0.c1, c0.c1.c0, ...,
+// c1.c2.c1.
+func (g GT) Serialize() []byte {
+	c := g.f.coefficients()
+	return appendFQs(make([]byte, 0, GTSize), c[:]...)
+}
+
+// DeserializeGT deserializes a GT element. It returns an error if the
+// encoding is not canonical or the element is not in GT.
+func DeserializeGT(b []byte) (*GT, error) {
+	if len(b) != GTSize {
+		return nil, errors.New("GT element must be 576 bytes")
+	}
+	c, err := readFQs(b)
+	if err != nil {
+		return nil, err
+	}
+	return newGTChecked(NewFQ12(
+		NewFQ6(NewFQ2(c[0], c[1]), NewFQ2(c[2], c[3]), NewFQ2(c[4], c[5])),
+		NewFQ6(NewFQ2(c[6], c[7]), NewFQ2(c[8], c[9]), NewFQ2(c[10], c[11])),
+	))
+}
+
+// CompressT2 compresses the element to half its size. Elements of GT have
+// norm one over FQ6, so they lie on the torus T2(FQ6) and are determined
+// by the single FQ6 element g = (1 + c0) / c1. The output is the six FQ
+// coefficients of g in the same order as Serialize.
+func (g GT) CompressT2() []byte {
+	t := g.f.torusCompress()
+	return appendFQs(make([]byte, 0, GTCompressedT2Size),
+		t.c0.c0, t.c0.c1, t.c1.c0, t.c1.c1, t.c2.c0, t.c2.c1)
+}
+
+// DecompressGTT2 decompresses an element compressed with CompressT2. It
+// returns an error if the encoding is not canonical or the element is not
+// in GT.
+func DecompressGTT2(b []byte) (*GT, error) {
+	if len(b) != GTCompressedT2Size {
+		return nil, errors.New("T2 compressed GT element must be 288 bytes")
+	}
+	c, err := readFQs(b)
+	if err != nil {
+		return nil, err
+	}
+	return newGTChecked(torusDecompress(NewFQ6(NewFQ2(c[0], c[1]), NewFQ2(c[2], c[3]), NewFQ2(c[4], c[5]))))
+}
+
+// Compress compresses the element to a third of its size. GT is also
+// contained in the torus T6(FQ2), which forces the T2 representative
+// g = g0 + g1*v + g2*v^2 to satisfy 3*g0*g1 = 1 + 3*(1 + u)*g2^2, so g0
+// can be dropped. The output is the coefficients of g1 and g2. One is
+// encoded as all zeros.
+func (g GT) Compress() []byte {
+	t := g.f.torusCompress()
+	return appendFQs(make([]byte, 0, GTCompressedSize),
+		t.c1.c0, t.c1.c1, t.c2.c0, t.c2.c1)
+}
+
+// DecompressGT decompresses an element compressed with Compress. It returns
+// an error if the encoding is not canonical or the element is not in GT.
+func DecompressGT(b []byte) (*GT, error) {
+	if len(b) != GTCompressedSize {
+		return nil, errors.New("compressed GT element must be 192 bytes")
+	}
+	c, err := readFQs(b)
+	if err != nil {
+		return nil, err
+	}
+	g1 := NewFQ2(c[0], c[1])
+	g2 := NewFQ2(c[2], c[3])
+
+	// (1 + u) is not a square, so g1 is only zero for the encoding of one
+	if g1.IsZero() {
+		if !g2.IsZero() {
+			return nil, errors.New("invalid compressed GT element")
+		}
+		return GTOne(), nil
+	}
+
+	// g0 = (1 + 3 * (1 + u) * g2^2) / (3 * g1)
+	g0 := g2.Square().MultiplyByNonresidue()
+	g0.AddAssign(g0.Double())
+	g0.AddAssign(FQ2One)
+	den := g1.Double()
+	den.AddAssign(g1)
+	g0.MulAssign(den.Inverse())
+
+	return newGTChecked(torusDecompress(NewFQ6(g0, g1, g2)))
+}
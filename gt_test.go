@@ -0,0 +1,244 @@
+package bls_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+func TestGTBilinear(t *testing.T) {
+	r := NewXORShift(1)
+	a, _ := bls.RandFR(r)
+	p, _ := bls.RandG1(r)
+	q, _ := bls.RandG2(r)
+
+	e := bls.PairingGT(p, q)
+	if !bls.PairingGT(p.MulFR(a), q).Equal(e.Exp(a)) {
+		t.Fatal("e(aP, Q) != e(P, Q)^a")
+	}
+	if !bls.PairingGT(p, q.MulFR(a)).Equal(e.Exp(a)) {
+		t.Fatal("e(P, aQ) != e(P, Q)^a")
+	}
+}
+
+func TestGTExp(t *testing.T) {
+	r := NewXORShift(2)
+	p, _ := bls.RandG1(r)
+	e := bls.PairingGT(p, bls.G2ProjectiveOne)
+	for i := 0; i < 5; i++ {
+		a, _ := bls.RandFR(r)
+		if !e.Exp(a).FQ12().Equals(e.FQ12().Exp(a.ToBig())) {
+			t.Fatal("GT exponentiation does not match FQ12 exponentiation")
+		}
+	}
+	if !e.Exp(bls.FRZero).IsOne() {
+		t.Fatal("e^0 should be one")
+	}
+	if !e.Exp(bls.FROne).Equal(e) {
+		t.Fatal("e^1 should be e")
+	}
+	minusOne := bls.FRZero.Sub(bls.FROne)
+	if !e.Exp(minusOne).Equal(e.Inverse()) {
+		t.Fatal("e^-1 should be the inverse of e")
+	}
+}
+
+func TestGTInverse(t *testing.T) {
+	r := NewXORShift(3)
+	p, _ := bls.RandG1(r)
+	q, _ := bls.RandG2(r)
+	e := bls.PairingGT(p, q)
+	if !e.Mul(e.Inverse()).IsOne() {
+		t.Fatal("e * e^-1 should be one")
+	}
+	if e.IsOne() {
+		t.Fatal("pairing of random points should not be one")
+	}
+	if !bls.GTOne().Inverse().IsOne() {
+		t.Fatal("inverse of one should be one")
+	}
+}
+
+func TestGTSubgroupCheck(t *testing.T) {
+	r := NewXORShift(4)
+	p, _ := bls.RandG1(r)
+	q, _ := bls.RandG2(r)
+	if !bls.PairingGT(p, q).IsInCorrectSubgroup() {
+		t.Fatal("pairing output should be in GT")
+	}
+	if !bls.GTOne().IsInCorrectSubgroup() {
+		t.Fatal("one should be in GT")
+	}
+
+	// (q^4 - q^2 + 1) / r clears the cofactor of the cyclotomic subgroup
+	q2 := new(big.Int).Mul(bls.QFieldModulus, bls.QFieldModulus)
+	h := new(big.Int).Mul(q2, q2)
+	h.Sub(h, q2)
+	h.Add(h, big.NewInt(1))
+	h.Div(h, bls.RFieldModulus)
+
+	for i := 0; i < 5; i++ {
+		f := randCyclotomic(r)
+		if _, err := bls.NewGT(f); err == nil {
+			t.Fatal("random cyclotomic element should not be in GT")
+		}
+		if _, err := bls.NewGT(f.Exp(h)); err != nil {
+			t.Fatal("cyclotomic element with cleared cofactor should be in GT")
+		}
+
+		f, _ = bls.RandFQ12(r)
+		if _, err := bls.NewGT(f); err == nil {
+			t.Fatal("random FQ12 element should not be in GT")
+		}
+	}
+	if _, err := bls.NewGT(bls.FQ12Zero); err == nil {
+		t.Fatal("zero should not be in GT")
+	}
+}
+
+func TestGTSerializeDeserialize(t *testing.T) {
+	r := NewXORShift(5)
+	p, _ := bls.RandG1(r)
+	q, _ := bls.RandG2(r)
+	e := bls.PairingGT(p, q)
+
+	b := e.Serialize()
+	if len(b) != bls.GTSize {
+		t.Fatalf("expected %d bytes, got %d", bls.GTSize, len(b))
+	}
+	out, err := bls.DeserializeGT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Equal(e) {
+		t.Fatal("GT element did not round-trip")
+	}
+
+	if _, err := bls.DeserializeGT(b[1:]); err == nil {
+		t.Fatal("expected short encoding to fail")
+	}
+
+	bad := append([]byte{}, b...)
+	bls.QFieldModulus.FillBytes(bad[:48])
+	if _, err := bls.DeserializeGT(bad); err == nil {
+		t.Fatal("expected non-canonical encoding to fail")
+	}
+
+	bad = append([]byte{}, b...)
+	bad[47] ^= 1
+	if _, err := bls.DeserializeGT(bad); err == nil {
+		t.Fatal("expected element outside GT to fail")
+	}
+}
+
+func TestGTCompress(t *testing.T) {
+	r := NewXORShift(6)
+	elems := []*bls.GT{bls.GTOne()}
+	for i := 0; i < 5; i++ {
+		p, _ := bls.RandG1(r)
+		q, _ := bls.RandG2(r)
+		e := bls.PairingGT(p, q)
+		elems = append(elems, e, e.Inverse())
+	}
+
+	for _, e := range elems {
+		b := e.Compress()
+		if len(b) != bls.GTCompressedSize {
+			t.Fatalf("expected %d bytes, got %d", bls.GTCompressedSize, len(b))
+		}
+		out, err := bls.DecompressGT(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !out.Equal(e) {
+			t.Fatal("T6 compressed GT element did not round-trip")
+		}
+
+		b = e.CompressT2()
+		if len(b) != bls.GTCompressedT2Size {
+			t.Fatalf("expected %d bytes, got %d", bls.GTCompressedT2Size, len(b))
+		}
+		out, err = bls.DecompressGTT2(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !out.Equal(e) {
+			t.Fatal("T2 compressed GT element did not round-trip")
+		}
+	}
+}
+
+func TestGTDecompressInvalid(t *testing.T) {
+	r := NewXORShift(7)
+	p, _ := bls.RandG1(r)
+	e := bls.PairingGT(p, bls.G2ProjectiveOne)
+
+	b := e.Compress()
+	if _, err := bls.DecompressGT(b[1:]); err == nil {
+		t.Fatal("expected short encoding to fail")
+	}
+	bad := append([]byte{}, b...)
+	bad[100] ^= 1
+	if _, err := bls.DecompressGT(bad); err == nil {
+		t.Fatal("expected element outside GT to fail")
+	}
+	bad = make([]byte, bls.GTCompressedSize)
+	bad[191] = 1
+	if _, err := bls.DecompressGT(bad); err == nil {
+		t.Fatal("expected g1 = 0 with g2 != 0 to fail")
+	}
+
+	b = e.CompressT2()
+	bad = append([]byte{}, b...)
+	bad[100] ^= 1
+	if _, err := bls.DecompressGTT2(bad); err == nil {
+		t.Fatal("expected element outside GT to fail")
+	}
+	bad = append([]byte{}, b...)
+	bls.QFieldModulus.FillBytes(bad[:48])
+	if _, err := bls.DecompressGTT2(bad); err == nil {
+		t.Fatal("expected non-canonical encoding to fail")
+	}
+}
+
+func BenchmarkGTDecompress(b *testing.B) {
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples][]byte{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		p, _ := bls.RandG1(r)
+		inData[i] = bls.PairingGT(p, bls.G2ProjectiveOne).Compress()
+	}
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		bls.DecompressGT(inData[count])
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
+
+func BenchmarkGTExp(b *testing.B) {
+	type expData struct {
+		g *bls.GT
+		f *bls.FR
+	}
+
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]expData{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		p, _ := bls.RandG1(r)
+		f, _ := bls.RandFR(r)
+		inData[i] = expData{
+			g: bls.PairingGT(p, bls.G2ProjectiveOne),
+			f: f,
+		}
+	}
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		inData[count].g.Exp(inData[count].f)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
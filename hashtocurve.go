@@ -0,0 +1,226 @@
+package bls
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// This file implements the hashing to field elements of RFC 9380,
+// "Hashing to Elliptic Curves". The curve specific maps are in
+// hashtocurve_g1.go and hashtocurve_g2.go.
+
+// oversizeDSTPrefix is prepended to domain separation tags longer than 255
+// bytes before they are hashed down (RFC 9380, section 5.3.3).
+var oversizeDSTPrefix = []byte("H2C-OVERSIZE-DST-")
+
+// ExpandMessageXMD expands a message into lenInBytes uniformly random
+// bytes using a Merkle-Damgard hash function such as SHA-256 (RFC 9380,
+// section 5.3.1).
+func ExpandMessageXMD(h func() hash.Hash, msg []byte, dst []byte, lenInBytes int) ([]byte, error) {
+	hasher := h()
+	bInBytes := hasher.Size()
+	sInBytes := hasher.BlockSize()
+
+	if len(dst) > 255 {
+		hasher.Write(oversizeDSTPrefix)
+		hasher.Write(dst)
+		dst = hasher.Sum(nil)
+		hasher.Reset()
+	}
+
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes > 65535 || lenInBytes < 0 {
+		return nil, errors.New("requested output is too long for expand_message_xmd")
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	hasher.Write(make([]byte, sInBytes))
+	hasher.Write(msg)
+	hasher.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	hasher.Write(dstPrime)
+	b0 := hasher.Sum(nil)
+
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	hasher.Reset()
+	hasher.Write(b0)
+	hasher.Write([]byte{1})
+	hasher.Write(dstPrime)
+	bi := hasher.Sum(nil)
+
+	out := make([]byte, 0, ell*bInBytes)
+	out = append(out, bi...)
+	for i := 2; i <= ell; i++ {
+		// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		hasher.Reset()
+		hasher.Write(bi)
+		hasher.Write([]byte{byte(i)})
+		hasher.Write(dstPrime)
+		bi = hasher.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[:lenInBytes], nil
+}
+
+// ExpandMessageXOF expands a message into lenInBytes uniformly random
+// bytes using an extendable-output function such as SHAKE128 (RFC 9380,
+// section 5.3.2). k is the target security level in bits and only
+// affects how domain separation tags longer than 255 bytes are shortened.
+func ExpandMessageXOF(h func() sha3.ShakeHash, k int, msg []byte, dst []byte, lenInBytes int) ([]byte, error) {
+	hasher := h()
+
+	if len(dst) > 255 {
+		hasher.Write(oversizeDSTPrefix)
+		hasher.Write(dst)
+		dst = make([]byte, (2*k+7)/8)
+		hasher.Read(dst)
+		hasher.Reset()
+	}
+
+	if lenInBytes > 65535 || lenInBytes < 0 {
+		return nil, errors.New("requested output is too long for expand_message_xof")
+	}
+
+	// H(msg || I2OSP(len_in_bytes, 2) || DST || I2OSP(len(DST), 1), len_in_bytes)
+	hasher.Write(msg)
+	hasher.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes)})
+	hasher.Write(dst)
+	hasher.Write([]byte{byte(len(dst))})
+	out := make([]byte, lenInBytes)
+	hasher.Read(out)
+	return out, nil
+}
+
+// Expander is an expand_message function with a fixed hash function. It
+// is used by the HashToField functions to derive uniform bytes.
+type Expander func(msg []byte, dst []byte, lenInBytes int) ([]byte, error)
+
+// NewXMDExpander returns an Expander that uses expand_message_xmd with the
+// given hash function, for example sha256.New.
+func NewXMDExpander(h func() hash.Hash) Expander {
+	return func(msg []byte, dst []byte, lenInBytes int) ([]byte, error) {
+		return ExpandMessageXMD(h, msg, dst, lenInBytes)
+	}
+}
+
+// NewXOFExpander returns an Expander that uses expand_message_xof with the
+// given extendable-output function and security level k in bits, for
+// example sha3.NewShake128 with k = 128.
+func NewXOFExpander(h func() sha3.ShakeHash, k int) Expander {
+	return func(msg []byte, dst []byte, lenInBytes int) ([]byte, error) {
+		return ExpandMessageXOF(h, k, msg, dst, lenInBytes)
+	}
+}
+
+// hashToFieldL is the number of bytes expanded for each FQ element,
+// ceil((ceil(log2(q)) + k) / 8) with security parameter k = 128.
+const hashToFieldL = 64
+
+// hashToFieldFRL is the number of bytes expanded for each FR element,
+// ceil((ceil(log2(r)) + k) / 8) with security parameter k = 128.
+const hashToFieldFRL = 48
+
+// hashToBigs expands a message and splits the output into count numbers
+// of l bytes each.
+func hashToBigs(expand Expander, msg []byte, dst []byte, count int, l int) ([]*big.Int, error) {
+	if count < 0 {
+		return nil, errors.New("count must not be negative")
+	}
+	uniform, err := expand(msg, dst, count*l)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*big.Int, count)
+	for i := range out {
+		out[i] = new(big.Int).SetBytes(uniform[i*l : (i+1)*l])
+	}
+	return out, nil
+}
+
+// HashToFieldFQ hashes a message to count FQ elements (RFC 9380, section
+// 5.2). dst is the domain separation tag of the application.
+func HashToFieldFQ(expand Expander, msg []byte, dst []byte, count int) ([]*FQ, error) {
+	ns, err := hashToBigs(expand, msg, dst, count, hashToFieldL)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*FQ, count)
+	for i, n := range ns {
+		out[i] = NewFQ(n)
+	}
+	return out, nil
+}
+
+// HashToFieldFQ2 hashes a message to count FQ2 elements (RFC 9380, section
+// 5.2). dst is the domain separation tag of the application.
+func HashToFieldFQ2(expand Expander, msg []byte, dst []byte, count int) ([]*FQ2, error) {
+	if count < 0 {
+		return nil, errors.New("count must not be negative")
+	}
+	fqs, err := HashToFieldFQ(expand, msg, dst, 2*count)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*FQ2, count)
+	for i := range out {
+		out[i] = NewFQ2(fqs[2*i], fqs[2*i+1])
+	}
+	return out, nil
+}
+
+// HashToFieldFR hashes a message to count FR elements in the manner of
+// RFC 9380, section 5.2, with the scalar field as the target field. dst
+// is the domain separation tag of the application.
+func HashToFieldFR(expand Expander, msg []byte, dst []byte, count int) ([]*FR, error) {
+	ns, err := hashToBigs(expand, msg, dst, count, hashToFieldFRL)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*FR, count)
+	for i, n := range ns {
+		out[i] = NewFR(n)
+	}
+	return out, nil
+}
+
+// fqSgn0 returns the sign of a field element as defined in RFC 9380,
+// section 4.1, which is the parity of its canonical value.
+func fqSgn0(f *FQ) bool {
+	return f.ToRepr().IsOdd()
+}
+
+// fq2Sgn0 returns the sign of an FQ2 element as defined in RFC 9380,
+// section 4.1. It is the sign of c0, or of c1 if c0 is zero.
+func fq2Sgn0(f *FQ2) bool {
+	return fqSgn0(f.c0) || (f.c0.IsZero() && fqSgn0(f.c1))
+}
+
+// fqsFromHex parses a list of hexadecimal constants into field elements.
+func fqsFromHex(hexes ...string) []*FQ {
+	out := make([]*FQ, len(hexes))
+	for i, h := range hexes {
+		n, ok := new(big.Int).SetString(h, 16)
+		if !ok {
+			panic("invalid hex constant " + h)
+		}
+		out[i] = NewFQ(n)
+	}
+	return out
+}
+
+// fq2sFromHex parses a list of hexadecimal constants into FQ2 elements,
+// taking c0 and c1 in turn.
+func fq2sFromHex(hexes ...string) []*FQ2 {
+	fqs := fqsFromHex(hexes...)
+	out := make([]*FQ2, len(fqs)/2)
+	for i := range out {
+		out[i] = NewFQ2(fqs[2*i], fqs[2*i+1])
+	}
+	return out
+}
@@ -0,0 +1,234 @@
+package bls
+
+import (
+	"math/big"
+)
+
+// The suites below hash to G2 as specified by RFC 9380, section 8.8.2. A
+// field element is mapped to the curve E': y^2 = x^3 + A'x + B' over FQ2,
+// which is 3-isogenous to the twist E2, with the simplified SWU map and
+// then moved to E2 with the isogeny.
+
+// SWU parameters of E': A' = 240u, B' = 1012(1 + u) and Z = -(2 + u).
+var (
+	g2SWUA = NewFQ2(FQZero, NewFQ(big.NewInt(240)))
+	g2SWUB = NewFQ2(NewFQ(big.NewInt(1012)), NewFQ(big.NewInt(1012)))
+	g2SWUZ = NewFQ2(NewFQ(big.NewInt(-2)), NewFQ(big.NewInt(-1)))
+
+	// -B' / A' and B' / (Z * A')
+	g2SWUMinusBOverA = g2SWUB.Neg().Mul(g2SWUA.Inverse())
+	g2SWUBOverZA     = g2SWUB.Mul(g2SWUZ.Mul(g2SWUA).Inverse())
+)
+
+// g2IsoXNum are the coefficients of the x numerator of the 3-isogeny,
+// lowest degree first, as pairs of c0 and c1.
+var g2IsoXNum = fq2sFromHex(
+	"5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6",
+	"5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6",
+	"0",
+	"11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71a",
+	"11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71e",
+	"8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38d",
+	"171d6541fa38ccfaed6dea691f5fb614cb14b4e7f4e810aa22d6108f142b85757098e38d0f671c7188e2aaaaaaaa5ed1",
+	"0",
+)
+
+// g2IsoXDen are the coefficients of the x denominator of the 3-isogeny,
+// lowest degree first, as pairs of c0 and c1.
+var g2IsoXDen = fq2sFromHex(
+	"0",
+	"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa63",
+	"c",
+	"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa9f",
+	"1",
+	"0",
+)
+
+// g2IsoYNum are the coefficients of the y numerator of the 3-isogeny,
+// lowest degree first, as pairs of c0 and c1.
+var g2IsoYNum = fq2sFromHex(
+	"1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706",
+	"1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706",
+	"0",
+	"5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97be",
+	"11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71c",
+	"8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38f",
+	"124c9ad43b6cf79bfbf7043de3811ad0761b0f37a1e26286b0e977c69aa274524e79097a56dc4bd9e1b371c71c718b10",
+	"0",
+)
+
+// g2IsoYDen are the coefficients of the y denominator of the 3-isogeny,
+// lowest degree first, as pairs of c0 and c1.
+var g2IsoYDen = fq2sFromHex(
+	"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb",
+	"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb",
+	"0",
+	"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa9d3",
+	"12",
+	"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa99",
+	"1",
+	"0",
+)
+
+// G2 hash-to-curve suite identifiers from RFC 9380.
+const (
+	// G2HashToCurveSuite is the suite ID of HashToG2.
+	G2HashToCurveSuite = "BLS12381G2_XMD:SHA-256_SSWU_RO_"
+
+	// G2EncodeToCurveSuite is the suite ID of EncodeToG2.
+	G2EncodeToCurveSuite = "BLS12381G2_XMD:SHA-256_SSWU_NU_"
+)
+
+// HashToG2 hashes a message to a G2 point with the
+// BLS12381G2_XMD:SHA-256_SSWU_RO_ suite. The output is indistinguishable
+// from a random oracle. dst is the domain separation tag of the
+// application.
+func HashToG2(msg []byte, dst []byte) *G2Projective {
+	u, _ := HashToFieldFQ2(expandMessageXMDSHA256, msg, dst, 2)
+	q0 := mapToCurveG2(u[0])
+	q1 := mapToCurveG2(u[1])
+	return clearCofactorG2(q0.Add(q1))
+}
+
+// EncodeToG2 hashes a message to a G2 point with the
+// BLS12381G2_XMD:SHA-256_SSWU_NU_ suite. It is about twice as fast as
+// HashToG2 but the output distribution is not uniform.
+func EncodeToG2(msg []byte, dst []byte) *G2Projective {
+	u, _ := HashToFieldFQ2(expandMessageXMDSHA256, msg, dst, 1)
+	return clearCofactorG2(mapToCurveG2(u[0]))
+}
+
+// mapToCurveG2 maps a field element to a point of E2 that is not
+// necessarily in G2.
+func mapToCurveG2(u *FQ2) *G2Projective {
+	x, y := swuMapG2(u)
+	return isoMapG2(x, y)
+}
+
+// swuMapG2 is the simplified SWU map onto E' (RFC 9380, section 6.6.2).
+func swuMapG2(u *FQ2) (*FQ2, *FQ2) {
+	// tv1 = 1 / (Z^2 * u^4 + Z * u^2)
+	zu2 := u.Square()
+	zu2.MulAssign(g2SWUZ)
+	tv1 := zu2.Square()
+	tv1.AddAssign(zu2)
+
+	var x1 *FQ2
+	if tv1.IsZero() {
+		x1 = g2SWUBOverZA.Copy()
+	} else {
+		// x1 = (-B / A) * (1 + tv1)
+		x1 = tv1.Inverse()
+		x1.AddAssign(FQ2One)
+		x1.MulAssign(g2SWUMinusBOverA)
+	}
+
+	x := x1
+	y := g2SWUCurve(x1).Sqrt()
+	if y == nil {
+		// x2 = Z * u^2 * x1, and g(x2) is square whenever g(x1) is not
+		x = zu2.Mul(x1)
+		y = g2SWUCurve(x).Sqrt()
+	}
+
+	if fq2Sgn0(u) != fq2Sgn0(y) {
+		y = y.Neg()
+	}
+	return x, y.Copy()
+}
+
+// g2SWUCurve returns x^3 + A' * x + B'.
+func g2SWUCurve(x *FQ2) *FQ2 {
+	out := x.Square()
+	out.AddAssign(g2SWUA)
+	out.MulAssign(x)
+	out.AddAssign(g2SWUB)
+	return out
+}
+
+// evalPolyFQ2 evaluates a polynomial with the given coefficients, lowest
+// degree first.
+func evalPolyFQ2(coeffs []*FQ2, x *FQ2) *FQ2 {
+	out := coeffs[len(coeffs)-1].Copy()
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		out.MulAssign(x)
+		out.AddAssign(coeffs[i])
+	}
+	return out
+}
+
+// isoMapG2 maps a point of E' to E2 with the 3-isogeny (RFC 9380,
+// appendix E.3).
+func isoMapG2(x *FQ2, y *FQ2) *G2Projective {
+	xDen := evalPolyFQ2(g2IsoXDen, x)
+	yDen := evalPolyFQ2(g2IsoYDen, x)
+	if xDen.IsZero() || yDen.IsZero() {
+		// the isogeny sends the points of its kernel to infinity
+		return G2ProjectiveZero.Copy()
+	}
+	xOut := evalPolyFQ2(g2IsoXNum, x)
+	xOut.MulAssign(xDen.Inverse())
+	yOut := evalPolyFQ2(g2IsoYNum, x)
+	yOut.MulAssign(yDen.Inverse())
+	yOut.MulAssign(y)
+	return NewG2Affine(xOut, yOut).ToProjective()
+}
+
+// Constants of the endomorphism psi = twist^-1 * frobenius * twist
+// (RFC 9380, appendix G.3).
+var (
+	// 1 / (1 + u)^((q - 1) / 3)
+	g2PsiCoeffX = fq2nqr.Exp(qMinus1Over3).Inverse()
+
+	// 1 / (1 + u)^((q - 1) / 2)
+	g2PsiCoeffY = fq2nqr.Exp(qMinus1Over2).Inverse()
+
+	// 1 / 2^((q - 1) / 3)
+	g2Psi2CoeffX = NewFQ2(NewFQ(bigTwo).Exp(qMinus1Over3).Inverse(), FQZero)
+)
+
+var qMinus1Over3 = new(big.Int).Div(new(big.Int).Sub(QFieldModulus, bigOne), bigThree)
+
+// g2Psi computes psi(P). The Frobenius map of FQ2 is a field automorphism,
+// so it can be applied to Jacobian coordinates directly.
+func g2Psi(p *G2Projective) *G2Projective {
+	return NewG2Projective(
+		p.x.FrobeniusMap(1).Mul(g2PsiCoeffX),
+		p.y.FrobeniusMap(1).Mul(g2PsiCoeffY),
+		p.z.FrobeniusMap(1).Copy(),
+	)
+}
+
+// g2Psi2 computes psi(psi(P)).
+func g2Psi2(p *G2Projective) *G2Projective {
+	return NewG2Projective(p.x.Mul(g2Psi2CoeffX), p.y.Neg(), p.z.Copy())
+}
+
+// g2MulByX multiplies a point by the curve parameter x, which is negative.
+func g2MulByX(p *G2Projective) *G2Projective {
+	out := p.Mul(blsX)
+	if blsIsNegative {
+		out.y = out.y.Neg()
+	}
+	return out
+}
+
+// clearCofactorG2 multiplies a point by the effective cofactor h_eff of
+// RFC 9380 using the method of Budroni and Pintore, which computes
+// [x^2 - x - 1]P + [x - 1]psi(P) + psi^2(2P) with two multiplications by
+// x instead of one by the 636-bit h_eff.
+func clearCofactorG2(p *G2Projective) *G2Projective {
+	t1 := g2MulByX(p)
+	t2 := g2Psi(p)
+	t3 := g2Psi2(p.Double())
+	t3 = t3.Add(g2Neg(t2))
+	t2 = g2MulByX(t1.Add(t2))
+	t3 = t3.Add(t2)
+	t3 = t3.Add(g2Neg(t1))
+	return t3.Add(g2Neg(p))
+}
+
+// g2Neg negates a point.
+func g2Neg(p *G2Projective) *G2Projective {
+	return NewG2Projective(p.x.Copy(), p.y.Neg(), p.z.Copy())
+}
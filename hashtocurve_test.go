@@ -0,0 +1,452 @@
+package bls_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/phoreproject/bls"
+	"golang.org/x/crypto/sha3"
+)
+
+type expandMessageVector struct {
+	msg          string
+	lenInBytes   int
+	uniformBytes string
+}
+
+type hashToG1Vector struct {
+	msg string
+	x   string
+	y   string
+}
+
+type hashToG2Vector struct {
+	msg string
+	x   [2]string
+	y   [2]string
+}
+
+// expandMessageXMDVectors are the expand_message_xmd SHA-256 vectors from RFC 9380,
+// appendix K.1.
+var expandMessageXMDVectors = []expandMessageVector{
+	{"", 32, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+	{"abc", 32, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+	{"abcdef0123456789", 32, "eff31487c770a893cfb36f912fbfcbff40d5661771ca4b2cb4eafe524333f5c1"},
+	{"q128_" + strings.Repeat("q", 128), 32, "b23a1d2b4d97b2ef7785562a7e8bac7eed54ed6e97e29aa51bfe3f12ddad1ff9"},
+	{"a512_" + strings.Repeat("a", 512), 32, "4623227bcc01293b8c130bf771da8c298dede7383243dc0993d2d94823958c4c"},
+	{"", 128, "af84c27ccfd45d41914fdff5df25293e221afc53d8ad2ac06d5e3e29485dadbee0d121587713a3e0dd4d5e69e93eb7cd4f5df4cd103e188cf60cb02edc3edf18eda8576c412b18ffb658e3dd6ec849469b979d444cf7b26911a08e63cf31f9dcc541708d3491184472c2c29bb749d4286b004ceb5ee6b9a7fa5b646c993f0ced"},
+	{"abc", 128, "abba86a6129e366fc877aab32fc4ffc70120d8996c88aee2fe4b32d6c7b6437a647e6c3163d40b76a73cf6a5674ef1d890f95b664ee0afa5359a5c4e07985635bbecbac65d747d3d2da7ec2b8221b17b0ca9dc8a1ac1c07ea6a1e60583e2cb00058e77b7b72a298425cd1b941ad4ec65e8afc50303a22c0f99b0509b4c895f40"},
+	{"abcdef0123456789", 128, "ef904a29bffc4cf9ee82832451c946ac3c8f8058ae97d8d629831a74c6572bd9ebd0df635cd1f208e2038e760c4994984ce73f0d55ea9f22af83ba4734569d4bc95e18350f740c07eef653cbb9f87910d833751825f0ebefa1abe5420bb52be14cf489b37fe1a72f7de2d10be453b2c9d9eb20c7e3f6edc5a60629178d9478df"},
+	{"q128_" + strings.Repeat("q", 128), 128, "80be107d0884f0d881bb460322f0443d38bd222db8bd0b0a5312a6fedb49c1bbd88fd75d8b9a09486c60123dfa1d73c1cc3169761b17476d3c6b7cbbd727acd0e2c942f4dd96ae3da5de368d26b32286e32de7e5a8cb2949f866a0b80c58116b29fa7fabb3ea7d520ee603e0c25bcaf0b9a5e92ec6a1fe4e0391d1cdbce8c68a"},
+	{"a512_" + strings.Repeat("a", 512), 128, "546aff5444b5b79aa6148bd81728704c32decb73a3ba76e9e75885cad9def1d06d6792f8a7d12794e90efed817d96920d728896a4510864370c207f99bd4a608ea121700ef01ed879745ee3e4ceef777eda6d9e5e38b90c86ea6fb0b36504ba4a45d22e86f6db5dd43d98a294bebb9125d5b794e9d2a81181066eb954966a487"},
+}
+
+// expandMessageXMDLongDSTVectors are the expand_message_xmd SHA-256 vectors from RFC 9380,
+// appendix K.1.
+var expandMessageXMDLongDSTVectors = []expandMessageVector{
+	{"", 32, "e8dc0c8b686b7ef2074086fbdd2f30e3f8bfbd3bdf177f73f04b97ce618a3ed3"},
+	{"abc", 32, "52dbf4f36cf560fca57dedec2ad924ee9c266341d8f3d6afe5171733b16bbb12"},
+	{"abcdef0123456789", 32, "35387dcf22618f3728e6c686490f8b431f76550b0b2c61cbc1ce7001536f4521"},
+	{"q128_" + strings.Repeat("q", 128), 32, "01b637612bb18e840028be900a833a74414140dde0c4754c198532c3a0ba42bc"},
+	{"a512_" + strings.Repeat("a", 512), 32, "20cce7033cabc5460743180be6fa8aac5a103f56d481cf369a8accc0c374431b"},
+	{"", 128, "14604d85432c68b757e485c8894db3117992fc57e0e136f71ad987f789a0abc287c47876978e2388a02af86b1e8d1342e5ce4f7aaa07a87321e691f6fba7e0072eecc1218aebb89fb14a0662322d5edbd873f0eb35260145cd4e64f748c5dfe60567e126604bcab1a3ee2dc0778102ae8a5cfd1429ebc0fa6bf1a53c36f55dfc"},
+	{"abc", 128, "1a30a5e36fbdb87077552b9d18b9f0aee16e80181d5b951d0471d55b66684914aef87dbb3626eaabf5ded8cd0686567e503853e5c84c259ba0efc37f71c839da2129fe81afdaec7fbdc0ccd4c794727a17c0d20ff0ea55e1389d6982d1241cb8d165762dbc39fb0cee4474d2cbbd468a835ae5b2f20e4f959f56ab24cd6fe267"},
+	{"abcdef0123456789", 128, "d2ecef3635d2397f34a9f86438d772db19ffe9924e28a1caf6f1c8f15603d4028f40891044e5c7e39ebb9b31339979ff33a4249206f67d4a1e7c765410bcd249ad78d407e303675918f20f26ce6d7027ed3774512ef5b00d816e51bfcc96c3539601fa48ef1c07e494bdc37054ba96ecb9dbd666417e3de289d4f424f502a982"},
+	{"q128_" + strings.Repeat("q", 128), 128, "ed6e8c036df90111410431431a232d41a32c86e296c05d426e5f44e75b9a50d335b2412bc6c91e0a6dc131de09c43110d9180d0a70f0d6289cb4e43b05f7ee5e9b3f42a1fad0f31bac6a625b3b5c50e3a83316783b649e5ecc9d3b1d9471cb5024b7ccf40d41d1751a04ca0356548bc6e703fca02ab521b505e8e45600508d32"},
+	{"a512_" + strings.Repeat("a", 512), 128, "78b53f2413f3c688f07732c10e5ced29a17c6a16f717179ffbe38d92d6c9ec296502eb9889af83a1928cd162e845b0d3c5424e83280fed3d10cffb2f8431f14e7a23f4c68819d40617589e4c41169d0b56e0e3535be1fd71fbb08bb70c5b5ffed953d6c14bf7618b35fc1f4c4b30538236b4b08c9fbf90462447a8ada60be495"},
+}
+
+// expandMessageXOFSHAKE128Vectors are the expand_message_xof SHAKE128 vectors from RFC 9380,
+// appendix K.3.
+var expandMessageXOFSHAKE128Vectors = []expandMessageVector{
+	{"", 32, "86518c9cd86581486e9485aa74ab35ba150d1c75c88e26b7043e44e2acd735a2"},
+	{"abc", 32, "8696af52a4d862417c0763556073f47bc9b9ba43c99b505305cb1ec04a9ab468"},
+	{"abcdef0123456789", 32, "912c58deac4821c3509dbefa094df54b34b8f5d01a191d1d3108a2c89077acca"},
+	{"q128_" + strings.Repeat("q", 128), 32, "1adbcc448aef2a0cebc71dac9f756b22e51839d348e031e63b33ebb50faeaf3f"},
+	{"a512_" + strings.Repeat("a", 512), 32, "df3447cc5f3e9a77da10f819218ddf31342c310778e0e4ef72bbaecee786a4fe"},
+	{"", 128, "7314ff1a155a2fb99a0171dc71b89ab6e3b2b7d59e38e64419b8b6294d03ffee42491f11370261f436220ef787f8f76f5b26bdcd850071920ce023f3ac46847744f4612b8714db8f5db83205b2e625d95afd7d7b4d3094d3bdde815f52850bb41ead9822e08f22cf41d615a303b0d9dde73263c049a7b9898208003a739a2e57"},
+	{"abc", 128, "c952f0c8e529ca8824acc6a4cab0e782fc3648c563ddb00da7399f2ae35654f4860ec671db2356ba7baa55a34a9d7f79197b60ddae6e64768a37d699a78323496db3878c8d64d909d0f8a7de4927dcab0d3dbbc26cb20a49eceb0530b431cdf47bc8c0fa3e0d88f53b318b6739fbed7d7634974f1b5c386d6230c76260d5337a"},
+	{"abcdef0123456789", 128, "19b65ee7afec6ac06a144f2d6134f08eeec185f1a890fe34e68f0e377b7d0312883c048d9b8a1d6ecc3b541cb4987c26f45e0c82691ea299b5e6889bbfe589153016d8131717ba26f07c3c14ffbef1f3eff9752e5b6183f43871a78219a75e7000fbac6a7072e2b83c790a3a5aecd9d14be79f9fd4fb180960a3772e08680495"},
+	{"q128_" + strings.Repeat("q", 128), 128, "ca1b56861482b16eae0f4a26212112362fcc2d76dcc80c93c4182ed66c5113fe41733ed68be2942a3487394317f3379856f4822a611735e50528a60e7ade8ec8c71670fec6661e2c59a09ed36386513221688b35dc47e3c3111ee8c67ff49579089d661caa29db1ef10eb6eace575bf3dc9806e7c4016bd50f3c0e2a6481ee6d"},
+	{"a512_" + strings.Repeat("a", 512), 128, "9d763a5ce58f65c91531b4100c7266d479a5d9777ba761693d052acd37d149e7ac91c796a10b919cd74a591a1e38719fb91b7203e2af31eac3bff7ead2c195af7d88b8bc0a8adf3d1e90ab9bed6ddc2b7f655dd86c730bdeaea884e73741097142c92f0e3fc1811b699ba593c7fbd81da288a29d423df831652e3a01a9374999"},
+}
+
+// expandMessageXOFSHAKE128LongDSTVectors are the expand_message_xof SHAKE128 vectors from RFC 9380,
+// appendix K.3.
+var expandMessageXOFSHAKE128LongDSTVectors = []expandMessageVector{
+	{"", 32, "827c6216330a122352312bccc0c8d6e7a146c5257a776dbd9ad9d75cd880fc53"},
+	{"abc", 32, "690c8d82c7213b4282c6cb41c00e31ea1d3e2005f93ad19bbf6da40f15790c5c"},
+	{"abcdef0123456789", 32, "979e3a15064afbbcf99f62cc09fa9c85028afcf3f825eb0711894dcfc2f57057"},
+	{"q128_" + strings.Repeat("q", 128), 32, "c5a9220962d9edc212c063f4f65b609755a1ed96e62f9db5d1fd6adb5a8dc52b"},
+	{"a512_" + strings.Repeat("a", 512), 32, "f7b96a5901af5d78ce1d071d9c383cac66a1dfadb508300ec6aeaea0d62d5d62"},
+	{"", 128, "3890dbab00a2830be398524b71c2713bbef5f4884ac2e6f070b092effdb19208c7df943dc5dcbaee3094a78c267ef276632ee2c8ea0c05363c94b6348500fae4208345dd3475fe0c834c2beac7fa7bc181692fb728c0a53d809fc8111495222ce0f38468b11becb15b32060218e285c57a60162c2c8bb5b6bded13973cd41819"},
+	{"abc", 128, "41b7ffa7a301b5c1441495ebb9774e2a53dbbf4e54b9a1af6a20fd41eafd69ef7b9418599c5545b1ee422f363642b01d4a53449313f68da3e49dddb9cd25b97465170537d45dcbdf92391b5bdff344db4bd06311a05bca7dcd360b6caec849c299133e5c9194f4e15e3e23cfaab4003fab776f6ac0bfae9144c6e2e1c62e7d57"},
+	{"abcdef0123456789", 128, "55317e4a21318472cd2290c3082957e1242241d9e0d04f47026f03401643131401071f01aa03038b2783e795bdfa8a3541c194ad5de7cb9c225133e24af6c86e748deb52e560569bd54ef4dac03465111a3a44b0ea490fb36777ff8ea9f1a8a3e8e0de3cf0880b4b2f8dd37d3a85a8b82375aee4fa0e909f9763319b55778e71"},
+	{"q128_" + strings.Repeat("q", 128), 128, "19fdd2639f082e31c77717ac9bb032a22ff0958382b2dbb39020cdc78f0da43305414806abf9a561cb2d0067eb2f7bc544482f75623438ed4b4e39dd9e6e2909dd858bd8f1d57cd0fce2d3150d90aa67b4498bdf2df98c0100dd1a173436ba5d0df6be1defb0b2ce55ccd2f4fc05eb7cb2c019c35d5398b85adc676da4238bc7"},
+	{"a512_" + strings.Repeat("a", 512), 128, "945373f0b3431a103333ba6a0a34f1efab2702efde41754c4cb1d5216d5b0a92a67458d968562bde7fa6310a83f53dda1383680a276a283438d58ceebfa7ab7ba72499d4a3eddc860595f63c93b1c5e823ea41fc490d938398a26db28f61857698553e93f0574eb8c5017bfed6249491f9976aaa8d23d9485339cc85ca329308"},
+}
+
+// expandMessageXOFSHAKE256Vectors are the expand_message_xof SHAKE256 vectors from RFC 9380,
+// appendix K.4.
+var expandMessageXOFSHAKE256Vectors = []expandMessageVector{
+	{"", 32, "2ffc05c48ed32b95d72e807f6eab9f7530dd1c2f013914c8fed38c5ccc15ad76"},
+	{"abc", 32, "b39e493867e2767216792abce1f2676c197c0692aed061560ead251821808e07"},
+	{"abcdef0123456789", 32, "245389cf44a13f0e70af8665fe5337ec2dcd138890bb7901c4ad9cfceb054b65"},
+	{"q128_" + strings.Repeat("q", 128), 32, "719b3911821e6428a5ed9b8e600f2866bcf23c8f0515e52d6c6c019a03f16f0e"},
+	{"a512_" + strings.Repeat("a", 512), 32, "9181ead5220b1963f1b5951f35547a5ea86a820562287d6ca4723633d17ccbbc"},
+	{"", 128, "7a1361d2d7d82d79e035b8880c5a3c86c5afa719478c007d96e6c88737a3f631dd74a2c88df79a4cb5e5d9f7504957c70d669ec6bfedc31e01e2bacc4ff3fdf9b6a00b17cc18d9d72ace7d6b81c2e481b4f73f34f9a7505dccbe8f5485f3d20c5409b0310093d5d6492dea4e18aa6979c23c8ea5de01582e9689612afbb353df"},
+	{"abc", 128, "a54303e6b172909783353ab05ef08dd435a558c3197db0c132134649708e0b9b4e34fb99b92a9e9e28fc1f1d8860d85897a8e021e6382f3eea10577f968ff6df6c45fe624ce65ca25932f679a42a404bc3681efe03fcd45ef73bb3a8f79ba784f80f55ea8a3c367408f30381299617f50c8cf8fbb21d0f1e1d70b0131a7b6fbe"},
+	{"abcdef0123456789", 128, "e42e4d9538a189316e3154b821c1bafb390f78b2f010ea404e6ac063deb8c0852fcd412e098e231e43427bd2be1330bb47b4039ad57b30ae1fc94e34993b162ff4d695e42d59d9777ea18d3848d9d336c25d2acb93adcad009bcfb9cde12286df267ada283063de0bb1505565b2eb6c90e31c48798ecdc71a71756a9110ff373"},
+	{"q128_" + strings.Repeat("q", 128), 128, "4ac054dda0a38a65d0ecf7afd3c2812300027c8789655e47aecf1ecc1a2426b17444c7482c99e5907afd9c25b991990490bb9c686f43e79b4471a23a703d4b02f23c669737a886a7ec28bddb92c3a98de63ebf878aa363a501a60055c048bea11840c4717beae7eee28c3cfa42857b3d130188571943a7bd747de831bd6444e0"},
+	{"a512_" + strings.Repeat("a", 512), 128, "09afc76d51c2cccbc129c2315df66c2be7295a231203b8ab2dd7f95c2772c68e500bc72e20c602abc9964663b7a03a389be128c56971ce81001a0b875e7fd17822db9d69792ddf6a23a151bf470079c518279aef3e75611f8f828994a9988f4a8a256ddb8bae161e658d5a2a09bcfe839c6396dc06ee5c8ff3c22d3b1f9deb7e"},
+}
+
+// hashToG1Vectors are the BLS12381G1_XMD:SHA-256_SSWU_RO_ vectors from RFC 9380,
+// appendix J.9.1, with DST QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_.
+var hashToG1Vectors = []hashToG1Vector{
+	{
+		"",
+		"052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1",
+		"08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265",
+	},
+	{
+		"abc",
+		"03567bc5ef9c690c2ab2ecdf6a96ef1c139cc0b2f284dca0a9a7943388a49a3aee664ba5379a7655d3c68900be2f6903",
+		"0b9c15f3fe6e5cf4211f346271d7b01c8f3b28be689c8429c85b67af215533311f0b8dfaaa154fa6b88176c229f2885d",
+	},
+	{
+		"abcdef0123456789",
+		"11e0b079dea29a68f0383ee94fed1b940995272407e3bb916bbf268c263ddd57a6a27200a784cbc248e84f357ce82d98",
+		"03a87ae2caf14e8ee52e51fa2ed8eefe80f02457004ba4d486d6aa1f517c0889501dc7413753f9599b099ebcbbd2d709",
+	},
+	{
+		"q128_" + strings.Repeat("q", 128),
+		"15f68eaa693b95ccb85215dc65fa81038d69629f70aeee0d0f677cf22285e7bf58d7cb86eefe8f2e9bc3f8cb84fac488",
+		"1807a1d50c29f430b8cafc4f8638dfeeadf51211e1602a5f184443076715f91bb90a48ba1e370edce6ae1062f5e6dd38",
+	},
+	{
+		"a512_" + strings.Repeat("a", 512),
+		"082aabae8b7dedb0e78aeb619ad3bfd9277a2f77ba7fad20ef6aabdc6c31d19ba5a6d12283553294c1825c4b3ca2dcfe",
+		"05b84ae5a942248eea39e1d91030458c40153f3b654ab7872d779ad1e942856a20c438e8d99bc8abfbf74729ce1f7ac8",
+	},
+}
+
+// encodeToG1Vectors are the BLS12381G1_XMD:SHA-256_SSWU_NU_ vectors from RFC 9380,
+// appendix J.9.1, with DST QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_NU_.
+var encodeToG1Vectors = []hashToG1Vector{
+	{
+		"",
+		"184bb665c37ff561a89ec2122dd343f20e0f4cbcaec84e3c3052ea81d1834e192c426074b02ed3dca4e7676ce4ce48ba",
+		"04407b8d35af4dacc809927071fc0405218f1401a6d15af775810e4e460064bcc9468beeba82fdc751be70476c888bf3",
+	},
+	{
+		"abc",
+		"009769f3ab59bfd551d53a5f846b9984c59b97d6842b20a2c565baa167945e3d026a3755b6345df8ec7e6acb6868ae6d",
+		"1532c00cf61aa3d0ce3e5aa20c3b531a2abd2c770a790a2613818303c6b830ffc0ecf6c357af3317b9575c567f11cd2c",
+	},
+	{
+		"abcdef0123456789",
+		"1974dbb8e6b5d20b84df7e625e2fbfecb2cdb5f77d5eae5fb2955e5ce7313cae8364bc2fff520a6c25619739c6bdcb6a",
+		"15f9897e11c6441eaa676de141c8d83c37aab8667173cbe1dfd6de74d11861b961dccebcd9d289ac633455dfcc7013a3",
+	},
+	{
+		"q128_" + strings.Repeat("q", 128),
+		"0a7a047c4a8397b3446450642c2ac64d7239b61872c9ae7a59707a8f4f950f101e766afe58223b3bff3a19a7f754027c",
+		"1383aebba1e4327ccff7cf9912bda0dbc77de048b71ef8c8a81111d71dc33c5e3aa6edee9cf6f5fe525d50cc50b77cc9",
+	},
+	{
+		"a512_" + strings.Repeat("a", 512),
+		"0e7a16a975904f131682edbb03d9560d3e48214c9986bd50417a77108d13dc957500edf96462a3d01e62dc6cd468ef11",
+		"0ae89e677711d05c30a48d6d75e76ca9fb70fe06c6dd6ff988683d89ccde29ac7d46c53bb97a59b1901abf1db66052db",
+	},
+}
+
+// hashToG2Vectors are the BLS12381G2_XMD:SHA-256_SSWU_RO_ vectors from RFC 9380,
+// appendix J.10.1, with DST QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_.
+var hashToG2Vectors = []hashToG2Vector{
+	{
+		"",
+		[2]string{
+			"0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a",
+			"05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d",
+		},
+		[2]string{
+			"0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+			"12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6",
+		},
+	},
+	{
+		"abc",
+		[2]string{
+			"02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6",
+			"139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8",
+		},
+		[2]string{
+			"1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48",
+			"00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16",
+		},
+	},
+	{
+		"abcdef0123456789",
+		[2]string{
+			"121982811d2491fde9ba7ed31ef9ca474f0e1501297f68c298e9f4c0028add35aea8bb83d53c08cfc007c1e005723cd0",
+			"190d119345b94fbd15497bcba94ecf7db2cbfd1e1fe7da034d26cbba169fb3968288b3fafb265f9ebd380512a71c3f2c",
+		},
+		[2]string{
+			"05571a0f8d3c08d094576981f4a3b8eda0a8e771fcdcc8ecceaf1356a6acf17574518acb506e435b639353c2e14827c8",
+			"0bb5e7572275c567462d91807de765611490205a941a5a6af3b1691bfe596c31225d3aabdf15faff860cb4ef17c7c3be",
+		},
+	},
+	{
+		"q128_" + strings.Repeat("q", 128),
+		[2]string{
+			"19a84dd7248a1066f737cc34502ee5555bd3c19f2ecdb3c7d9e24dc65d4e25e50d83f0f77105e955d78f4762d33c17da",
+			"0934aba516a52d8ae479939a91998299c76d39cc0c035cd18813bec433f587e2d7a4fef038260eef0cef4d02aae3eb91",
+		},
+		[2]string{
+			"14f81cd421617428bc3b9fe25afbb751d934a00493524bc4e065635b0555084dd54679df1536101b2c979c0152d09192",
+			"09bcccfa036b4847c9950780733633f13619994394c23ff0b32fa6b795844f4a0673e20282d07bc69641cee04f5e5662",
+		},
+	},
+	{
+		"a512_" + strings.Repeat("a", 512),
+		[2]string{
+			"01a6ba2f9a11fa5598b2d8ace0fbe0a0eacb65deceb476fbbcb64fd24557c2f4b18ecfc5663e54ae16a84f5ab7f62534",
+			"11fca2ff525572795a801eed17eb12785887c7b63fb77a42be46ce4a34131d71f7a73e95fee3f812aea3de78b4d01569",
+		},
+		[2]string{
+			"0b6798718c8aed24bc19cb27f866f1c9effcdbf92397ad6448b5c9db90d2b9da6cbabf48adc1adf59a1a28344e79d57e",
+			"03a47f8e6d1763ba0cad63d6114c0accbef65707825a511b251a660a9b3994249ae4e63fac38b23da0c398689ee2ab52",
+		},
+	},
+}
+
+// encodeToG2Vectors are the BLS12381G2_XMD:SHA-256_SSWU_NU_ vectors from RFC 9380,
+// appendix J.10.2, with DST QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_NU_.
+var encodeToG2Vectors = []hashToG2Vector{
+	{
+		"",
+		[2]string{
+			"00e7f4568a82b4b7dc1f14c6aaa055edf51502319c723c4dc2688c7fe5944c213f510328082396515734b6612c4e7bb7",
+			"126b855e9e69b1f691f816e48ac6977664d24d99f8724868a184186469ddfd4617367e94527d4b74fc86413483afb35b",
+		},
+		[2]string{
+			"0caead0fd7b6176c01436833c79d305c78be307da5f6af6c133c47311def6ff1e0babf57a0fb5539fce7ee12407b0a42",
+			"1498aadcf7ae2b345243e281ae076df6de84455d766ab6fcdaad71fab60abb2e8b980a440043cd305db09d283c895e3d",
+		},
+	},
+	{
+		"abc",
+		[2]string{
+			"108ed59fd9fae381abfd1d6bce2fd2fa220990f0f837fa30e0f27914ed6e1454db0d1ee957b219f61da6ff8be0d6441f",
+			"0296238ea82c6d4adb3c838ee3cb2346049c90b96d602d7bb1b469b905c9228be25c627bffee872def773d5b2a2eb57d",
+		},
+		[2]string{
+			"033f90f6057aadacae7963b0a0b379dd46750c1c94a6357c99b65f63b79e321ff50fe3053330911c56b6ceea08fee656",
+			"153606c417e59fb331b7ae6bce4fbf7c5190c33ce9402b5ebe2b70e44fca614f3f1382a3625ed5493843d0b0a652fc3f",
+		},
+	},
+	{
+		"abcdef0123456789",
+		[2]string{
+			"038af300ef34c7759a6caaa4e69363cafeed218a1f207e93b2c70d91a1263d375d6730bd6b6509dcac3ba5b567e85bf3",
+			"0da75be60fb6aa0e9e3143e40c42796edf15685cafe0279afd2a67c3dff1c82341f17effd402e4f1af240ea90f4b659b",
+		},
+		[2]string{
+			"19b148cbdf163cf0894f29660d2e7bfb2b68e37d54cc83fd4e6e62c020eaa48709302ef8e746736c0e19342cc1ce3df4",
+			"0492f4fed741b073e5a82580f7c663f9b79e036b70ab3e51162359cec4e77c78086fe879b65ca7a47d34374c8315ac5e",
+		},
+	},
+	{
+		"q128_" + strings.Repeat("q", 128),
+		[2]string{
+			"0c5ae723be00e6c3f0efe184fdc0702b64588fe77dda152ab13099a3bacd3876767fa7bbad6d6fd90b3642e902b208f9",
+			"12c8c05c1d5fc7bfa847f4d7d81e294e66b9a78bc9953990c358945e1f042eedafce608b67fdd3ab0cb2e6e263b9b1ad",
+		},
+		[2]string{
+			"04e77ddb3ede41b5ec4396b7421dd916efc68a358a0d7425bddd253547f2fb4830522358491827265dfc5bcc1928a569",
+			"11c624c56dbe154d759d021eec60fab3d8b852395a89de497e48504366feedd4662d023af447d66926a28076813dd646",
+		},
+	},
+	{
+		"a512_" + strings.Repeat("a", 512),
+		[2]string{
+			"0ea4e7c33d43e17cc516a72f76437c4bf81d8f4eac69ac355d3bf9b71b8138d55dc10fd458be115afa798b55dac34be1",
+			"1565c2f625032d232f13121d3cfb476f45275c303a037faa255f9da62000c2c864ea881e2bcddd111edc4a3c0da3e88d",
+		},
+		[2]string{
+			"043b6f5fe4e52c839148dc66f2b3751e69a0f6ebb3d056d6465d50d4108543ecd956e10fa1640dfd9bc0030cc2558d28",
+			"0f8991d2a1ad662e7b6f58ab787947f1fa607fce12dde171bc17903b012091b657e15333e11701edcf5b63ba2a561247",
+		},
+	},
+}
+
+func testExpandMessage(t *testing.T, expand bls.Expander, dst string, vectors []expandMessageVector) {
+	for _, v := range vectors {
+		out, err := expand([]byte(v.msg), []byte(dst), v.lenInBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := hex.DecodeString(v.uniformBytes)
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("expand_message(%q, %d) = %x, expected %x", v.msg, v.lenInBytes, out, expected)
+		}
+	}
+}
+
+func TestExpandMessageXMD(t *testing.T) {
+	expand := bls.NewXMDExpander(sha256.New)
+	testExpandMessage(t, expand, "QUUX-V01-CS02-with-expander-SHA256-128", expandMessageXMDVectors)
+	testExpandMessage(t, expand, "QUUX-V01-CS02-with-expander-SHA256-128-long-DST-"+strings.Repeat("1", 208), expandMessageXMDLongDSTVectors)
+
+	if _, err := bls.ExpandMessageXMD(sha256.New, nil, []byte("dst"), 256*32); err == nil {
+		t.Fatal("expected an error for a too long output")
+	}
+}
+
+func TestExpandMessageXOF(t *testing.T) {
+	shake128 := bls.NewXOFExpander(sha3.NewShake128, 128)
+	testExpandMessage(t, shake128, "QUUX-V01-CS02-with-expander-SHAKE128", expandMessageXOFSHAKE128Vectors)
+	testExpandMessage(t, shake128, "QUUX-V01-CS02-with-expander-SHAKE128-long-DST-"+strings.Repeat("1", 210), expandMessageXOFSHAKE128LongDSTVectors)
+	testExpandMessage(t, bls.NewXOFExpander(sha3.NewShake256, 256), "QUUX-V01-CS02-with-expander-SHAKE256", expandMessageXOFSHAKE256Vectors)
+
+	if _, err := bls.ExpandMessageXOF(sha3.NewShake128, 128, nil, []byte("dst"), 65536); err == nil {
+		t.Fatal("expected an error for a too long output")
+	}
+}
+
+func TestHashToFieldFQ(t *testing.T) {
+	// u values of the BLS12381G1_XMD:SHA-256_SSWU_RO_ vector for "abc"
+	expected := []*bls.FQ{
+		hexToFQ("0d921c33f2bad966478a03ca35d05719bdf92d347557ea166e5bba579eea9b83e9afa5c088573c2281410369fbd32951"),
+		hexToFQ("003574a00b109ada2f26a37a91f9d1e740dffd8d69ec0c35e1e9f4652c7dba61123e9dd2e76c655d956e2b3462611139"),
+	}
+	u, err := bls.HashToFieldFQ(bls.NewXMDExpander(sha256.New), []byte("abc"), []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range expected {
+		if !u[i].Equals(expected[i]) {
+			t.Fatalf("u[%d] = %s, expected %s", i, u[i], expected[i])
+		}
+	}
+}
+
+func TestHashToFieldFQ2(t *testing.T) {
+	// u values of the BLS12381G2_XMD:SHA-256_SSWU_RO_ vector for "abc"
+	expected := []*bls.FQ2{
+		bls.NewFQ2(
+			hexToFQ("15f7c0aa8f6b296ab5ff9c2c7581ade64f4ee6f1bf18f55179ff44a2cf355fa53dd2a2158c5ecb17d7c52f63e7195771"),
+			hexToFQ("01c8067bf4c0ba709aa8b9abc3d1cef589a4758e09ef53732d670fd8739a7274e111ba2fcaa71b3d33df2a3a0c8529dd"),
+		),
+		bls.NewFQ2(
+			hexToFQ("187111d5e088b6b9acfdfad078c4dacf72dcd17ca17c82be35e79f8c372a693f60a033b461d81b025864a0ad051a06e4"),
+			hexToFQ("08b852331c96ed983e497ebc6dee9b75e373d923b729194af8e72a051ea586f3538a6ebb1e80881a082fa2b24df9f566"),
+		),
+	}
+	u, err := bls.HashToFieldFQ2(bls.NewXMDExpander(sha256.New), []byte("abc"), []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range expected {
+		if !u[i].Equals(expected[i]) {
+			t.Fatalf("u[%d] = %s, expected %s", i, u[i], expected[i])
+		}
+	}
+}
+
+func TestHashToFieldFR(t *testing.T) {
+	expand := bls.NewXOFExpander(sha3.NewShake128, 128)
+	msg := []byte("abc")
+	dst := []byte("BLS-TEST-FR-")
+
+	u, err := bls.HashToFieldFR(expand, msg, dst, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uniform, _ := expand(msg, dst, 3*48)
+	for i := range u {
+		n := new(big.Int).SetBytes(uniform[i*48 : (i+1)*48])
+		expected := bls.NewFR(n)
+		if !u[i].Equals(expected) {
+			t.Fatalf("u[%d] = %s, expected %s", i, u[i], expected)
+		}
+	}
+
+	if _, err := bls.HashToFieldFR(expand, msg, dst, 2000); err == nil {
+		t.Fatal("expected an error for a too long output")
+	}
+	if _, err := bls.HashToFieldFR(expand, msg, dst, -1); err == nil {
+		t.Fatal("expected an error for a negative count")
+	}
+}
+
+func hexToFQ(s string) *bls.FQ {
+	n, _ := new(big.Int).SetString(s, 16)
+	return bls.NewFQ(n)
+}
+
+func testHashToG1(t *testing.T, hashFunc func([]byte, []byte) *bls.G1Projective, dst string, vectors []hashToG1Vector) {
+	for _, v := range vectors {
+		p := hashFunc([]byte(v.msg), []byte(dst))
+		expected := bls.NewG1Affine(hexToFQ(v.x), hexToFQ(v.y))
+		if !p.ToAffine().Equals(expected) {
+			t.Fatalf("hash of %q is %s, expected %s", v.msg, p.ToAffine(), expected)
+		}
+		if !p.ToAffine().IsInCorrectSubgroupAssumingOnCurve() {
+			t.Fatalf("hash of %q is not in G1", v.msg)
+		}
+	}
+}
+
+func TestHashToG1(t *testing.T) {
+	testHashToG1(t, bls.HashToG1, "QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_", hashToG1Vectors)
+}
+
+func TestEncodeToG1(t *testing.T) {
+	testHashToG1(t, bls.EncodeToG1, "QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_NU_", encodeToG1Vectors)
+}
+
+func testHashToG2(t *testing.T, hashFunc func([]byte, []byte) *bls.G2Projective, dst string, vectors []hashToG2Vector) {
+	for _, v := range vectors {
+		p := hashFunc([]byte(v.msg), []byte(dst))
+		expected := bls.NewG2Affine(
+			bls.NewFQ2(hexToFQ(v.x[0]), hexToFQ(v.x[1])),
+			bls.NewFQ2(hexToFQ(v.y[0]), hexToFQ(v.y[1])),
+		)
+		if !p.ToAffine().Equals(expected) {
+			t.Fatalf("hash of %q is %s, expected %s", v.msg, p.ToAffine(), expected)
+		}
+		if !p.ToAffine().IsInCorrectSubgroupAssumingOnCurve() {
+			t.Fatalf("hash of %q is not in G2", v.msg)
+		}
+	}
+}
+
+func TestHashToG2(t *testing.T) {
+	testHashToG2(t, bls.HashToG2, "QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_", hashToG2Vectors)
+}
+
+func TestEncodeToG2(t *testing.T) {
+	testHashToG2(t, bls.EncodeToG2, "QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_NU_", encodeToG2Vectors)
+}
+
+func BenchmarkHashToG1(b *testing.B) {
+	dst := []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_")
+	msg := []byte(">16 character identical message")
+	for i := 0; i < b.N; i++ {
+		bls.HashToG1(msg, dst)
+	}
+}
+
+func BenchmarkHashToG2(b *testing.B) {
+	dst := []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+	msg := []byte(">16 character identical message")
+	for i := 0; i < b.N; i++ {
+		bls.HashToG2(msg, dst)
+	}
+}
@@ -0,0 +1,54 @@
+package bls
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// keyGenSalt is the initial HKDF salt of KeyGen.
+var keyGenSalt = []byte("BLS-SIG-KEYGEN-SALT-")
+
+// keyGenL is the number of HKDF output bytes reduced to a secret key,
+// ceil((3 * ceil(log2(r))) / 16).
+const keyGenL = 48
+
+// KeyGen deterministically derives a secret key from secret input keying
+// material as specified by draft-irtf-cfrg-bls-signature, section 2.3.
+// ikm must be at least 32 bytes of high entropy secret data, such as a
+// stored seed. keyInfo is optional and can be used to derive several
+// independent keys from the same ikm.
+//
+// The key is derived with HKDF-SHA256 from the hash of the salt. If the
+// derived key is zero, which happens with negligible probability, the salt
+// is hashed again and the derivation is repeated.
+func KeyGen(ikm []byte, keyInfo []byte) (*SecretKey, error) {
+	if len(ikm) < 32 {
+		return nil, errors.New("input keying material must be at least 32 bytes")
+	}
+	return hkdfModR(ikm, keyInfo, keyGenSalt)
+}
+
+// hkdfModR derives a nonzero scalar from ikm with HKDF-SHA256. The salt is
+// hashed before every attempt, so the first one uses H(salt).
+func hkdfModR(ikm []byte, keyInfo []byte, salt []byte) (*SecretKey, error) {
+	// PRK = HKDF-Extract(salt, IKM || I2OSP(0, 1))
+	// OKM = HKDF-Expand(PRK, key_info || I2OSP(L, 2), L)
+	ikmZero := append(append([]byte{}, ikm...), 0)
+	info := append(append([]byte{}, keyInfo...), keyGenL>>8, keyGenL&0xff)
+	for {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		okm := make([]byte, keyGenL)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, ikmZero, salt, info), okm); err != nil {
+			return nil, err
+		}
+		sk := NewFR(new(big.Int).SetBytes(okm))
+		if !sk.IsZero() {
+			return &SecretKey{f: sk}, nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package bls_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+func TestKeyGen(t *testing.T) {
+	// the seed of the first EIP-2333 test case, whose master secret key is
+	// KeyGen of the seed with no key info
+	ikm := mustDecodeHex("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+	sk, err := bls.KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "0d7359d57963ab8fbbde1852dcf553fedbc31f464d80ee7d40ae683122b45070"
+	if hex.EncodeToString(sk.Serialize()) != expected {
+		t.Fatalf("KeyGen returned %x, expected %s", sk.Serialize(), expected)
+	}
+
+	sk2, _ := bls.NewBasicScheme().KeyGen(ikm)
+	if !bytes.Equal(sk.Serialize(), sk2.Serialize()) {
+		t.Fatal("scheme KeyGen does not match KeyGen")
+	}
+}
+
+func TestKeyGenKeyInfo(t *testing.T) {
+	ikm := bytes.Repeat([]byte{0x42}, 32)
+	sk1, _ := bls.KeyGen(ikm, []byte("key 1"))
+	sk2, _ := bls.KeyGen(ikm, []byte("key 2"))
+	sk3, _ := bls.KeyGen(ikm, []byte("key 1"))
+	if bytes.Equal(sk1.Serialize(), sk2.Serialize()) {
+		t.Fatal("different key info derived the same key")
+	}
+	if !bytes.Equal(sk1.Serialize(), sk3.Serialize()) {
+		t.Fatal("KeyGen is not deterministic")
+	}
+	if ikm[0] != 0x42 || len(ikm) != 32 {
+		t.Fatal("KeyGen modified its input")
+	}
+}
+
+func TestKeyGenShortIKM(t *testing.T) {
+	if _, err := bls.KeyGen(make([]byte, 31), nil); err == nil {
+		t.Fatal("expected an error for short input keying material")
+	}
+	if _, err := bls.NewBasicScheme().KeyGen(make([]byte, 31)); err == nil {
+		t.Fatal("expected an error for short input keying material")
+	}
+}
@@ -0,0 +1,287 @@
+package bls
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+// This file implements the EIP-2335 keystore format, which stores a secret
+// key encrypted with a password. Keystores are interoperable with other
+// Ethereum consensus clients, so the public key stored in them is the
+// min-pk (G1) public key.
+
+// Key derivation functions of a keystore.
+const (
+	// KeystoreKDFScrypt derives the decryption key with scrypt.
+	KeystoreKDFScrypt = "scrypt"
+
+	// KeystoreKDFPBKDF2 derives the decryption key with PBKDF2-HMAC-SHA256.
+	KeystoreKDFPBKDF2 = "pbkdf2"
+)
+
+// keystoreVersion is the version of the keystore format.
+const keystoreVersion = 4
+
+// Parameters used for new keystores, as recommended by EIP-2335.
+const (
+	keystoreDKLen   = 32
+	keystoreScryptN = 262144
+	keystoreScryptR = 8
+	keystoreScryptP = 1
+	keystorePBKDF2C = 262144
+)
+
+// Keystore is an EIP-2335 keystore. It is encoded to and decoded from JSON
+// with the encoding/json package.
+type Keystore struct {
+	Crypto      KeystoreCrypto `json:"crypto"`
+	Description string         `json:"description,omitempty"`
+	Pubkey      string         `json:"pubkey"`
+	Path        string         `json:"path"`
+	UUID        string         `json:"uuid"`
+	Version     int            `json:"version"`
+}
+
+// KeystoreCrypto holds the modules that encrypt the secret key.
+type KeystoreCrypto struct {
+	KDF      KeystoreModule `json:"kdf"`
+	Checksum KeystoreModule `json:"checksum"`
+	Cipher   KeystoreModule `json:"cipher"`
+}
+
+// KeystoreModule is one step of the encryption. Its parameters depend on
+// the function.
+type KeystoreModule struct {
+	Function string          `json:"function"`
+	Params   json.RawMessage `json:"params"`
+	Message  string          `json:"message"`
+}
+
+type keystoreScryptParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+type keystorePBKDF2Params struct {
+	DKLen int    `json:"dklen"`
+	C     int    `json:"c"`
+	PRF   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+// keystorePassword normalizes a password to NFKD and removes the C0, C1
+// and delete control codes.
+func keystorePassword(password string) []byte {
+	var out []byte
+	for _, r := range norm.NFKD.String(password) {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			continue
+		}
+		out = append(out, string(r)...)
+	}
+	return out
+}
+
+// NewKeystore encrypts a secret key with a password. kdf is
+// KeystoreKDFScrypt or KeystoreKDFPBKDF2 and path is the EIP-2334 path the
+// key was derived with, or empty. Salts, the IV and the UUID are read from
+// r, which should be a cryptographically secure random source.
+func NewKeystore(sk *SecretKey, password string, path string, kdf string, r io.Reader) (*Keystore, error) {
+	var random [32 + 16 + 16]byte
+	if _, err := io.ReadFull(r, random[:]); err != nil {
+		return nil, err
+	}
+	salt := hex.EncodeToString(random[:32])
+	iv := random[32:48]
+	uuid := random[48:]
+
+	var kdfParams interface{}
+	switch kdf {
+	case KeystoreKDFScrypt:
+		kdfParams = keystoreScryptParams{keystoreDKLen, keystoreScryptN, keystoreScryptP, keystoreScryptR, salt}
+	case KeystoreKDFPBKDF2:
+		kdfParams = keystorePBKDF2Params{keystoreDKLen, keystorePBKDF2C, "hmac-sha256", salt}
+	default:
+		return nil, fmt.Errorf("unknown key derivation function %q", kdf)
+	}
+	kdfParamsJSON, err := json.Marshal(kdfParams)
+	if err != nil {
+		return nil, err
+	}
+	cipherParamsJSON, err := json.Marshal(keystoreCipherParams{hex.EncodeToString(iv)})
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Keystore{
+		Crypto: KeystoreCrypto{
+			KDF:      KeystoreModule{Function: kdf, Params: kdfParamsJSON},
+			Checksum: KeystoreModule{Function: "sha256", Params: json.RawMessage("{}")},
+			Cipher:   KeystoreModule{Function: "aes-128-ctr", Params: cipherParamsJSON},
+		},
+		Pubkey:  hex.EncodeToString(minPkLayout{}.skToPk(sk)),
+		Path:    path,
+		UUID:    formatUUID(uuid),
+		Version: keystoreVersion,
+	}
+
+	key, err := k.decryptionKey(password)
+	if err != nil {
+		return nil, err
+	}
+	secret := sk.f.Bytes()
+	ciphertext, err := aes128CTR(key[:16], iv, secret[:])
+	if err != nil {
+		return nil, err
+	}
+	k.Crypto.Cipher.Message = hex.EncodeToString(ciphertext)
+	k.Crypto.Checksum.Message = hex.EncodeToString(keystoreChecksum(key, ciphertext))
+	return k, nil
+}
+
+// formatUUID formats 16 random bytes as a version 4 UUID.
+func formatUUID(b []byte) string {
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Decrypt decrypts the secret key with a password. It fails if the
+// password is wrong.
+func (k *Keystore) Decrypt(password string) (*SecretKey, error) {
+	if k.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", k.Version)
+	}
+	if k.Crypto.Checksum.Function != "sha256" {
+		return nil, fmt.Errorf("unknown checksum function %q", k.Crypto.Checksum.Function)
+	}
+	if k.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, fmt.Errorf("unknown cipher function %q", k.Crypto.Cipher.Function)
+	}
+
+	key, err := k.decryptionKey(password)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := hex.DecodeString(k.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := hex.DecodeString(k.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(keystoreChecksum(key, ciphertext), checksum) != 1 {
+		return nil, errors.New("keystore checksum does not match, the password is probably wrong")
+	}
+
+	var params keystoreCipherParams
+	if err := json.Unmarshal(k.Crypto.Cipher.Params, &params); err != nil {
+		return nil, err
+	}
+	iv, err := hex.DecodeString(params.IV)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := aes128CTR(key[:16], iv, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) != 32 {
+		return nil, errors.New("encrypted secret key has the wrong length")
+	}
+	var b [32]byte
+	copy(b[:], secret)
+	f, err := FRFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return &SecretKey{f: f}, nil
+}
+
+// decryptionKey derives the decryption key from a password with the key
+// derivation function of the keystore.
+func (k *Keystore) decryptionKey(password string) ([]byte, error) {
+	var dkLen int
+	var salt string
+	var derive func(password []byte, salt []byte) ([]byte, error)
+
+	switch k.Crypto.KDF.Function {
+	case KeystoreKDFScrypt:
+		var params keystoreScryptParams
+		if err := json.Unmarshal(k.Crypto.KDF.Params, &params); err != nil {
+			return nil, err
+		}
+		dkLen, salt = params.DKLen, params.Salt
+		derive = func(password []byte, salt []byte) ([]byte, error) {
+			return scrypt.Key(password, salt, params.N, params.R, params.P, params.DKLen)
+		}
+	case KeystoreKDFPBKDF2:
+		var params keystorePBKDF2Params
+		if err := json.Unmarshal(k.Crypto.KDF.Params, &params); err != nil {
+			return nil, err
+		}
+		if params.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("unknown pseudorandom function %q", params.PRF)
+		}
+		if params.C < 1 {
+			return nil, errors.New("pbkdf2 iteration count must be positive")
+		}
+		dkLen, salt = params.DKLen, params.Salt
+		derive = func(password []byte, salt []byte) ([]byte, error) {
+			return pbkdf2.Key(password, salt, params.C, params.DKLen, sha256.New), nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown key derivation function %q", k.Crypto.KDF.Function)
+	}
+
+	// the first half of the key encrypts and the second half authenticates
+	if dkLen < 32 {
+		return nil, errors.New("derived key must be at least 32 bytes")
+	}
+	saltBytes, err := hex.DecodeString(salt)
+	if err != nil {
+		return nil, err
+	}
+	return derive(keystorePassword(password), saltBytes)
+}
+
+// keystoreChecksum is SHA-256(decryption_key[16:32] || cipher_message).
+func keystoreChecksum(key []byte, ciphertext []byte) []byte {
+	h := sha256.New()
+	h.Write(key[16:32])
+	h.Write(ciphertext)
+	return h.Sum(nil)
+}
+
+// aes128CTR encrypts or decrypts with AES-128 in counter mode.
+func aes128CTR(key []byte, iv []byte, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, errors.New("cipher IV has the wrong length")
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
@@ -0,0 +1,165 @@
+package bls_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+// the test vectors of EIP-2335
+const keystorePassword = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+
+const keystoreSecret = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+
+const keystorePubkey = "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07"
+
+var keystoreVectors = []string{`{
+    "crypto": {
+        "kdf": {
+            "function": "scrypt",
+            "params": {
+                "dklen": 32,
+                "n": 262144,
+                "p": 1,
+                "r": 8,
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"
+        }
+    },
+    "description": "This is a test keystore that uses scrypt to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/3141592/1",
+    "uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+    "version": 4
+}`, `{
+    "crypto": {
+        "kdf": {
+            "function": "pbkdf2",
+            "params": {
+                "dklen": 32,
+                "c": 262144,
+                "prf": "hmac-sha256",
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+        }
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`}
+
+func TestKeystoreVectors(t *testing.T) {
+	for i, v := range keystoreVectors {
+		var k bls.Keystore
+		if err := json.Unmarshal([]byte(v), &k); err != nil {
+			t.Fatal(err)
+		}
+		sk, err := k.Decrypt(keystorePassword)
+		if err != nil {
+			t.Fatalf("vector %d: %s", i, err)
+		}
+		if hex.EncodeToString(sk.Serialize()) != keystoreSecret {
+			t.Fatalf("vector %d: decrypted %x, expected %s", i, sk.Serialize(), keystoreSecret)
+		}
+		pk := bls.NewProofOfPossessionSchemeMinPk().SkToPk(sk)
+		if hex.EncodeToString(pk) != keystorePubkey {
+			t.Fatalf("vector %d: public key %x does not match the keystore", i, pk)
+		}
+
+		// the password is normalized, so its NFKD form works too
+		if _, err := k.Decrypt("testpassword\U0001f511"); err != nil {
+			t.Fatalf("vector %d: normalized password failed: %s", i, err)
+		}
+		if _, err := k.Decrypt("testpassword"); err == nil {
+			t.Fatalf("vector %d: wrong password decrypted the keystore", i)
+		}
+	}
+}
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	r := NewXORShift(1)
+	sk, _ := bls.RandKey(r)
+
+	for _, kdf := range []string{bls.KeystoreKDFScrypt, bls.KeystoreKDFPBKDF2} {
+		k, err := bls.NewKeystore(sk, "password", "m/12381/3600/0/0/0", kdf, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k.Version != 4 || k.Path != "m/12381/3600/0/0/0" || len(k.UUID) != 36 || k.UUID[14] != '4' {
+			t.Fatalf("%s: keystore has unexpected fields", kdf)
+		}
+
+		b, err := json.Marshal(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var k2 bls.Keystore
+		if err := json.Unmarshal(b, &k2); err != nil {
+			t.Fatal(err)
+		}
+
+		sk2, err := k2.Decrypt("password")
+		if err != nil {
+			t.Fatalf("%s: %s", kdf, err)
+		}
+		if !bytes.Equal(sk.Serialize(), sk2.Serialize()) {
+			t.Fatalf("%s: decrypted a different secret key", kdf)
+		}
+		if _, err := k2.Decrypt("passwort"); err == nil {
+			t.Fatalf("%s: wrong password decrypted the keystore", kdf)
+		}
+	}
+}
+
+func TestKeystoreControlCharacters(t *testing.T) {
+	r := NewXORShift(2)
+	sk, _ := bls.RandKey(r)
+
+	k, err := bls.NewKeystore(sk, "pass\x7fwo\u0085rd\n", "", bls.KeystoreKDFPBKDF2, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.Decrypt("password"); err != nil {
+		t.Fatal("control characters are not removed from the password")
+	}
+}
+
+func TestKeystoreUnknownKDF(t *testing.T) {
+	r := NewXORShift(3)
+	sk, _ := bls.RandKey(r)
+
+	if _, err := bls.NewKeystore(sk, "password", "", "argon2", r); err == nil {
+		t.Fatal("unknown key derivation function was accepted")
+	}
+}
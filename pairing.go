@@ -18,26 +18,24 @@ type pairingItem struct {
 
 // MillerLoop runs the miller loop algorithm.
 func MillerLoop(items []MillerLoopItem) *FQ12 {
-	pairs := make([]pairingItem, len(items))
-	for i, item := range items {
+	// pairs with a point at infinity contribute one to the product
+	pairs := make([]pairingItem, 0, len(items))
+	for _, item := range items {
 		if !item.P.IsZero() && !item.Q.IsZero() {
-			pairs[i] = pairingItem{
+			pairs = append(pairs, pairingItem{
 				p:      item.P.Copy(),
 				q:      item.Q.coeffs,
 				qIndex: 0,
-			}
+			})
 		}
 	}
 
+	// ell evaluates a line at p. The coefficients belong to the prepared
+	// point, which may be shared between goroutines, so they are never
+	// modified.
 	ell := func(f *FQ12, coeffs [3]*FQ2, p *G1Affine) *FQ12 {
-
-		c0 := coeffs[0]
-		c1 := coeffs[1]
-
-		c0.c0.MulAssign(p.y)
-		c0.c1.MulAssign(p.y)
-		c1.c0.MulAssign(p.x)
-		c1.c1.MulAssign(p.x)
+		c0 := NewFQ2(coeffs[0].c0.Mul(p.y), coeffs[0].c1.Mul(p.y))
+		c1 := NewFQ2(coeffs[1].c0.Mul(p.x), coeffs[1].c1.Mul(p.x))
 
 		return f.MulBy014(coeffs[2], c1, c0)
 	}
@@ -93,8 +91,10 @@ func FinalExponentiation(r *FQ12) *FQ12 {
 	r.FrobeniusMapAssign(2)
 	r.MulAssign(f2)
 
+	// Everything from here on is in the cyclotomic subgroup, so squarings
+	// can use the cheaper cyclotomic formulas.
 	ExpByX := func(f *FQ12, x *big.Int) *FQ12 {
-		newf := f.Exp(x)
+		newf := f.cyclotomicExpCompressed(x)
 		if blsIsNegative {
 			newf.ConjugateAssign()
 		}
@@ -103,7 +103,7 @@ func FinalExponentiation(r *FQ12) *FQ12 {
 
 	x := new(big.Int).Set(blsX)
 
-	y0 := r.Square()
+	y0 := r.CyclotomicSquare()
 	y1 := ExpByX(y0, x)
 	x.Rsh(x, 1)
 	y2 := ExpByX(y1, x)
@@ -135,3 +135,35 @@ func Pairing(p *G1Projective, q *G2Projective) *FQ12 {
 		{p.ToAffine(), G2AffineToPrepared(q.ToAffine())},
 	}))
 }
+
+// PairingCheck checks that the product of the pairings of each G1 point
+// with the matching G2 point is one. All pairs share a single Miller loop
+// and a single final exponentiation, which is much cheaper than computing
+// each pairing separately. It returns false if the slices have different
+// lengths.
+func PairingCheck(g1s []*G1Projective, g2s []*G2Projective) bool {
+	if len(g1s) != len(g2s) {
+		return false
+	}
+	prepared := make([]*G2Prepared, len(g2s))
+	for i := range g2s {
+		prepared[i] = G2AffineToPrepared(g2s[i].ToAffine())
+	}
+	return pairingCheckPrepared(g1s, prepared)
+}
+
+// pairingCheckPrepared is PairingCheck for G2 points that are already
+// prepared.
+func pairingCheckPrepared(g1s []*G1Projective, g2s []*G2Prepared) bool {
+	if len(g1s) != len(g2s) {
+		return false
+	}
+	items := make([]MillerLoopItem, len(g1s))
+	for i := range g1s {
+		items[i] = MillerLoopItem{
+			P: g1s[i].ToAffine(),
+			Q: g2s[i],
+		}
+	}
+	return FinalExponentiation(MillerLoop(items)).Equals(FQ12One)
+}
@@ -2,6 +2,7 @@ package bls_test
 
 import (
 	"math/big"
+	"sync"
 	"testing"
 
 	"github.com/phoreproject/bls"
@@ -58,6 +59,113 @@ func TestResultAgainstRelic(t *testing.T) {
 	}
 }
 
+func TestPairingCheck(t *testing.T) {
+	r := NewXORShift(1)
+	a, _ := bls.RandFR(r)
+	p, _ := bls.RandG1(r)
+	q, _ := bls.RandG2(r)
+
+	// e(a*p, q) * e(-p, a*q) == 1
+	negP := p.ToAffine().Neg().ToProjective()
+	if !bls.PairingCheck(
+		[]*bls.G1Projective{p.MulFR(a), negP},
+		[]*bls.G2Projective{q, q.MulFR(a)},
+	) {
+		t.Fatal("pairing check failed for a valid product")
+	}
+	if bls.PairingCheck(
+		[]*bls.G1Projective{p.MulFR(a), p},
+		[]*bls.G2Projective{q, q.MulFR(a)},
+	) {
+		t.Fatal("pairing check passed for an invalid product")
+	}
+
+	if !bls.PairingCheck(nil, nil) {
+		t.Fatal("empty product should be one")
+	}
+	if !bls.PairingCheck(
+		[]*bls.G1Projective{bls.G1ProjectiveZero, p},
+		[]*bls.G2Projective{q, bls.G2ProjectiveZero},
+	) {
+		t.Fatal("pairings with infinity should be one")
+	}
+	if bls.PairingCheck([]*bls.G1Projective{p}, nil) {
+		t.Fatal("pairing check should fail for mismatched lengths")
+	}
+}
+
+func BenchmarkPairingCheck(b *testing.B) {
+	type pairingData struct {
+		g1 []*bls.G1Projective
+		g2 []*bls.G2Projective
+	}
+	r := NewXORShift(1)
+	inData := [g1MulAssignSamples]pairingData{}
+	for i := 0; i < g1MulAssignSamples; i++ {
+		f2, _ := bls.RandG2(r)
+		f1, _ := bls.RandG1(r)
+		g2, _ := bls.RandG2(r)
+		g1, _ := bls.RandG1(r)
+		inData[i] = pairingData{
+			g1: []*bls.G1Projective{f1, g1},
+			g2: []*bls.G2Projective{f2, g2},
+		}
+	}
+
+	b.ResetTimer()
+
+	count := 0
+	for i := 0; i < b.N; i++ {
+		bls.PairingCheck(inData[count].g1, inData[count].g2)
+		count = (count + 1) % g1MulAssignSamples
+	}
+}
+
+func TestG2PreparedReusable(t *testing.T) {
+	r := NewXORShift(1)
+	q, _ := bls.RandG2(r)
+	prepared := bls.G2AffineToPrepared(q.ToAffine())
+	for i := 0; i < 3; i++ {
+		p, _ := bls.RandG1(r)
+		out := bls.FinalExponentiation(bls.MillerLoop([]bls.MillerLoopItem{{P: p.ToAffine(), Q: prepared}}))
+		if !out.Equals(bls.Pairing(p, q)) {
+			t.Fatal("prepared point was modified by the Miller loop")
+		}
+	}
+}
+
+// TestG2PreparedConcurrent runs Miller loops over one prepared point from
+// several goroutines. Run with -race to check that nothing writes to it.
+func TestG2PreparedConcurrent(t *testing.T) {
+	r := NewXORShift(1)
+	q, _ := bls.RandG2(r)
+	prepared := bls.G2AffineToPrepared(q.ToAffine())
+
+	const workers = 8
+	ps := make([]*bls.G1Affine, workers)
+	expected := make([]*bls.FQ12, workers)
+	for i := range ps {
+		p, _ := bls.RandG1(r)
+		ps[i] = p.ToAffine()
+		expected[i] = bls.MillerLoop([]bls.MillerLoopItem{{P: ps[i], Q: bls.G2AffineToPrepared(q.ToAffine())}})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 2; j++ {
+				out := bls.MillerLoop([]bls.MillerLoopItem{{P: ps[i], Q: prepared}})
+				if !out.Equals(expected[i]) {
+					t.Error("concurrent Miller loop gave the wrong result")
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
 func BenchmarkG2Prepare(b *testing.B) {
 	type addData struct {
 		g2 *bls.G2Affine
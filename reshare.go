@@ -0,0 +1,115 @@
+package bls
+
+import (
+	"errors"
+	"io"
+)
+
+// This file implements proactive refresh and resharing of a threshold
+// key. Both run the phases of the DKG, but the dealers share values that
+// keep the group secret key the same:
+//
+// In a refresh, every member deals a sharing of zero and adds the shares
+// it receives to its own. In a resharing, every member of the old
+// committee deals its own share to the new committee, and the new shares
+// are the Lagrange interpolation of the deals of t old members. The
+// commitments prove that dealers shared zero or their own share.
+//
+// Shares from before a refresh or resharing lie on a different polynomial
+// than the shares after it, so they cannot be combined with the new ones.
+// Old shares should be deleted once the new ones are in use.
+
+// NewRefreshParticipant creates a participant of a proactive refresh of a
+// group of n members with the given share and group commitment, such as
+// the result of SplitSecretKeyVerifiable or the DKG. Members are addressed
+// by their share index on the transport.
+func NewRefreshParticipant(share *SecretKeyShare, commitment *VSSCommitment, n int, transport DKGTransport, r io.Reader) (*DKGParticipant, error) {
+	t := commitment.Threshold()
+	if err := checkThreshold(t, n); err != nil {
+		return nil, err
+	}
+	if share.Index < 1 || uint64(share.Index) > uint64(n) {
+		return nil, errors.New("share index must be between 1 and n")
+	}
+	if !commitment.VerifyShare(share) {
+		return nil, errors.New("share does not match the commitment")
+	}
+	members := make([]uint32, n)
+	for i := range members {
+		members[i] = uint32(i + 1)
+	}
+	p := newDKGParticipant(share.Index, members, members, t, transport, r)
+	p.mode = dkgRefresh
+	p.oldShare = share
+	p.oldCommitment = commitment
+	return p, nil
+}
+
+// ReshareConfig describes the committees of a resharing.
+type ReshareConfig struct {
+	// OldMembers are the addresses of the old committee on the transport.
+	// The member at position i holds the share with index i+1.
+	OldMembers []uint32
+
+	// OldCommitment is the group commitment of the old committee.
+	OldCommitment *VSSCommitment
+
+	// NewMembers are the addresses of the new committee. The member at
+	// position i receives the share with index i+1. Members can belong to
+	// both committees.
+	NewMembers []uint32
+
+	// NewThreshold is the number of new shares needed to sign.
+	NewThreshold int
+}
+
+// checkMembers checks that a committee has no duplicate addresses.
+func checkMembers(members []uint32) error {
+	seen := make(map[uint32]bool, len(members))
+	for _, m := range members {
+		if seen[m] {
+			return errors.New("duplicate committee member")
+		}
+		seen[m] = true
+	}
+	return nil
+}
+
+// NewReshareParticipant creates the participant with the given address of
+// a resharing from an old committee to a new one. share is the old share
+// of the participant, or nil if it is not in the old committee.
+func NewReshareParticipant(address uint32, config *ReshareConfig, share *SecretKeyShare, transport DKGTransport, r io.Reader) (*DKGParticipant, error) {
+	if err := checkThreshold(config.OldCommitment.Threshold(), len(config.OldMembers)); err != nil {
+		return nil, err
+	}
+	if err := checkThreshold(config.NewThreshold, len(config.NewMembers)); err != nil {
+		return nil, err
+	}
+	if err := checkMembers(config.OldMembers); err != nil {
+		return nil, err
+	}
+	if err := checkMembers(config.NewMembers); err != nil {
+		return nil, err
+	}
+
+	oldIndex := memberIndex(config.OldMembers, address)
+	if oldIndex == 0 && memberIndex(config.NewMembers, address) == 0 {
+		return nil, errors.New("participant is not in either committee")
+	}
+	if oldIndex != 0 {
+		if share == nil || share.Index != oldIndex {
+			return nil, errors.New("old committee member needs its share")
+		}
+		if !config.OldCommitment.VerifyShare(share) {
+			return nil, errors.New("share does not match the commitment")
+		}
+	}
+
+	p := newDKGParticipant(address, config.OldMembers, config.NewMembers, config.NewThreshold, transport, r)
+	p.mode = dkgReshare
+	if oldIndex != 0 {
+		p.oldShare = share
+	}
+	p.oldCommitment = config.OldCommitment
+	return p, nil
+}
@@ -0,0 +1,303 @@
+package bls_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+// recoverWithShares signs a message with key shares and recovers the
+// group signature from them.
+func recoverWithShares(t *testing.T, shares []*bls.SecretKeyShare, msg []byte) *bls.Signature {
+	sigs := make([]*bls.SignatureShare, len(shares))
+	for i, share := range shares {
+		sigs[i] = share.Sign(msg, 0)
+	}
+	sig, err := bls.RecoverSignature(sigs, len(sigs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func TestRefresh(t *testing.T) {
+	r := NewXORShift(1)
+	sk, _ := bls.RandKey(r)
+	pub := bls.PrivToPub(sk)
+	msg := []byte("refresh message")
+	oldShares, commitment, _ := bls.SplitSecretKeyVerifiable(sk, 3, 5, r)
+
+	network := bls.NewMemoryNetwork()
+	transports := make([]bls.DKGTransport, len(oldShares))
+	for i, share := range oldShares {
+		transports[i] = network.Transport(share.Index)
+	}
+	participants := make([]*bls.DKGParticipant, len(oldShares))
+	for i, share := range oldShares {
+		p, err := bls.NewRefreshParticipant(share, commitment, 5, transports[i], r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		participants[i] = p
+	}
+	results := runPhases(t, participants)
+
+	newShares := make([]*bls.SecretKeyShare, len(results))
+	for i, result := range results {
+		if !result.PublicKey.Equals(*pub) {
+			t.Fatal("refresh changed the group public key")
+		}
+		if !reflect.DeepEqual(result.Qualified, []uint32{1, 2, 3, 4, 5}) {
+			t.Fatalf("refresh qualified %v", result.Qualified)
+		}
+		newShares[i] = result.Share
+		if newShares[i].Index != oldShares[i].Index {
+			t.Fatal("refresh changed the share index")
+		}
+		if bytes.Equal(newShares[i].Key.Serialize(), oldShares[i].Key.Serialize()) {
+			t.Fatal("refresh did not change the share")
+		}
+		if !result.Commitment.VerifyShare(newShares[i]) {
+			t.Fatal("refreshed share does not match the refreshed commitment")
+		}
+		if result.Commitment.VerifyShare(oldShares[i]) {
+			t.Fatal("old share matches the refreshed commitment")
+		}
+	}
+
+	if !bls.Verify(msg, pub, recoverWithShares(t, newShares[2:], msg), 0) {
+		t.Fatal("refreshed shares did not recover a valid signature")
+	}
+	mixed := []*bls.SecretKeyShare{oldShares[0], oldShares[1], newShares[4]}
+	if bls.Verify(msg, pub, recoverWithShares(t, mixed, msg), 0) {
+		t.Fatal("old shares combined with a refreshed share")
+	}
+	sig := oldShares[0].Sign(msg, 0)
+	if bls.VerifySignatureShare(msg, results[0].Commitment.PublicKeyShare(1), sig, 0) {
+		t.Fatal("old signature share verified against the refreshed commitment")
+	}
+}
+
+func TestRefreshShareMismatch(t *testing.T) {
+	r := NewXORShift(2)
+	sk, _ := bls.RandKey(r)
+	shares, commitment, _ := bls.SplitSecretKeyVerifiable(sk, 2, 3, r)
+	_, otherCommitment, _ := bls.SplitSecretKeyVerifiable(sk, 2, 3, r)
+	network := bls.NewMemoryNetwork()
+
+	if _, err := bls.NewRefreshParticipant(shares[0], otherCommitment, 3, network.Transport(1), r); err == nil {
+		t.Fatal("refresh accepted a share of another commitment")
+	}
+	if _, err := bls.NewRefreshParticipant(shares[0], commitment, 1, network.Transport(1), r); err == nil {
+		t.Fatal("refresh accepted a group smaller than the threshold")
+	}
+}
+
+// runReshare reshares a 2-of-3 key held by addresses 1 to 3 to a 3-of-5
+// committee with addresses 3 to 7. Old members in absent do not take part
+// and wrap can replace the transport of a participant.
+func runReshare(t *testing.T, absent map[uint32]bool, wrap func(address uint32, transport bls.DKGTransport) bls.DKGTransport) (*bls.SecretKey, []*bls.SecretKeyShare, []*bls.DKGResult, error) {
+	r := NewXORShift(3)
+	sk, _ := bls.RandKey(r)
+	oldShares, commitment, _ := bls.SplitSecretKeyVerifiable(sk, 2, 3, r)
+	config := &bls.ReshareConfig{
+		OldMembers:    []uint32{1, 2, 3},
+		OldCommitment: commitment,
+		NewMembers:    []uint32{3, 4, 5, 6, 7},
+		NewThreshold:  3,
+	}
+
+	network := bls.NewMemoryNetwork()
+	transports := make(map[uint32]bls.DKGTransport)
+	for address := uint32(1); address <= 7; address++ {
+		transports[address] = network.Transport(address)
+	}
+	var participants []*bls.DKGParticipant
+	for address := uint32(1); address <= 7; address++ {
+		if absent[address] {
+			continue
+		}
+		var share *bls.SecretKeyShare
+		if address <= 3 {
+			share = oldShares[address-1]
+		}
+		transport := transports[address]
+		if wrap != nil {
+			transport = wrap(address, transport)
+		}
+		p, err := bls.NewReshareParticipant(address, config, share, transport, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		participants = append(participants, p)
+	}
+
+	phases := []func(p *bls.DKGParticipant) error{
+		(*bls.DKGParticipant).Deal,
+		(*bls.DKGParticipant).Verify,
+		(*bls.DKGParticipant).Complain,
+		(*bls.DKGParticipant).Justify,
+	}
+	for _, phase := range phases {
+		for _, p := range participants {
+			if err := phase(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	var results []*bls.DKGResult
+	for _, p := range participants {
+		result, err := p.Finalize()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		results = append(results, result)
+	}
+	return sk, oldShares, results, nil
+}
+
+func TestReshare(t *testing.T) {
+	sk, oldShares, results, err := runReshare(t, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := bls.PrivToPub(sk)
+	msg := []byte("reshare message")
+
+	var newShares []*bls.SecretKeyShare
+	for i, result := range results {
+		if !result.PublicKey.Equals(*pub) {
+			t.Fatal("resharing changed the group public key")
+		}
+		if !reflect.DeepEqual(result.Qualified, []uint32{1, 2, 3}) {
+			t.Fatalf("resharing qualified %v", result.Qualified)
+		}
+		if result.Commitment.Threshold() != 3 {
+			t.Fatal("new commitment has the wrong threshold")
+		}
+		// addresses 1 and 2 leave the committee
+		if i < 2 {
+			if result.Share != nil {
+				t.Fatal("member of the old committee only got a new share")
+			}
+			continue
+		}
+		if result.Share.Index != uint32(i-1) {
+			t.Fatalf("new member %d got share index %d", i+1, result.Share.Index)
+		}
+		if !result.Commitment.VerifyShare(result.Share) {
+			t.Fatal("new share does not match the new commitment")
+		}
+		newShares = append(newShares, result.Share)
+	}
+
+	for start := 0; start+3 <= len(newShares); start++ {
+		if !bls.Verify(msg, pub, recoverWithShares(t, newShares[start:start+3], msg), 0) {
+			t.Fatal("new shares did not recover a valid signature")
+		}
+	}
+	if bls.Verify(msg, pub, recoverWithShares(t, newShares[:2], msg), 0) {
+		t.Fatal("two new shares recovered the signature of a 3-of-5 key")
+	}
+
+	// the old shares reconstruct a different polynomial than the new ones
+	mixed := []*bls.SecretKeyShare{oldShares[0], newShares[1], newShares[2]}
+	if bls.Verify(msg, pub, recoverWithShares(t, mixed, msg), 0) {
+		t.Fatal("an old share combined with new shares")
+	}
+	if results[2].Commitment.VerifyShare(oldShares[0]) {
+		t.Fatal("old share matches the new commitment")
+	}
+}
+
+func TestReshareMissingOldMember(t *testing.T) {
+	// two old members are enough to reshare a 2-of-3 key
+	sk, _, results, err := runReshare(t, map[uint32]bool{1: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results {
+		if !result.PublicKey.Equals(*bls.PrivToPub(sk)) {
+			t.Fatal("resharing changed the group public key")
+		}
+		if !reflect.DeepEqual(result.Qualified, []uint32{2, 3}) {
+			t.Fatalf("resharing qualified %v", result.Qualified)
+		}
+	}
+
+	if _, _, _, err := runReshare(t, map[uint32]bool{1: true, 2: true}, nil); err == nil {
+		t.Fatal("one old member reshared a 2-of-3 key")
+	}
+}
+
+// wrongSecretTransport replaces the commitment of a dealer with one that
+// does not commit to its old share.
+type wrongSecretTransport struct {
+	bls.DKGTransport
+	commitment *bls.VSSCommitment
+}
+
+func (w *wrongSecretTransport) Broadcast(msg *bls.DKGMessage) error {
+	if msg.Type == bls.DKGCommitment {
+		msg = &bls.DKGMessage{Type: bls.DKGCommitment, Commitment: w.commitment}
+	}
+	return w.DKGTransport.Broadcast(msg)
+}
+
+func TestReshareDisqualifyWrongSecret(t *testing.T) {
+	r := NewXORShift(4)
+	other, _ := bls.RandKey(r)
+	_, commitment, _ := bls.SplitSecretKeyVerifiable(other, 3, 5, r)
+
+	sk, _, results, err := runReshare(t, nil, func(address uint32, transport bls.DKGTransport) bls.DKGTransport {
+		if address != 2 {
+			return transport
+		}
+		return &wrongSecretTransport{DKGTransport: transport, commitment: commitment}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the dishonest dealer does not see its own commitment replaced
+	for _, result := range append(results[:1], results[2:]...) {
+		if !result.PublicKey.Equals(*bls.PrivToPub(sk)) {
+			t.Fatal("resharing changed the group public key")
+		}
+		if !reflect.DeepEqual(result.Qualified, []uint32{1, 3}) {
+			t.Fatalf("resharing qualified %v", result.Qualified)
+		}
+	}
+}
+
+func TestReshareConfig(t *testing.T) {
+	r := NewXORShift(5)
+	sk, _ := bls.RandKey(r)
+	shares, commitment, _ := bls.SplitSecretKeyVerifiable(sk, 2, 3, r)
+	network := bls.NewMemoryNetwork()
+	config := &bls.ReshareConfig{
+		OldMembers:    []uint32{1, 2, 3},
+		OldCommitment: commitment,
+		NewMembers:    []uint32{4, 5, 6},
+		NewThreshold:  2,
+	}
+
+	if _, err := bls.NewReshareParticipant(1, config, nil, network.Transport(1), r); err == nil {
+		t.Fatal("old member without a share was accepted")
+	}
+	if _, err := bls.NewReshareParticipant(1, config, shares[1], network.Transport(1), r); err == nil {
+		t.Fatal("old member with another member's share was accepted")
+	}
+	if _, err := bls.NewReshareParticipant(8, config, nil, network.Transport(8), r); err == nil {
+		t.Fatal("participant outside both committees was accepted")
+	}
+	if _, err := bls.NewReshareParticipant(4, config, nil, network.Transport(4), r); err != nil {
+		t.Fatal(err)
+	}
+
+	config.NewMembers = []uint32{4, 5, 4}
+	if _, err := bls.NewReshareParticipant(4, config, nil, network.Transport(4), r); err == nil {
+		t.Fatal("duplicate committee member was accepted")
+	}
+}
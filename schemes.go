@@ -0,0 +1,455 @@
+package bls
+
+import "errors"
+
+// This file implements the three signature schemes of
+// draft-irtf-cfrg-bls-signature. Each scheme comes in the min-sig layout,
+// where signatures are G1 points and public keys are G2 points, and the
+// min-pk layout, which swaps the groups and is used by Ethereum, Chia and
+// Filecoin. Keys and signatures are passed around as octet strings in the
+// standard compressed encoding so that they can be exchanged with other
+// implementations.
+
+// Domain separation tags of the min-sig ciphersuites.
+const (
+	// BasicSchemeDST is the DST of the basic scheme.
+	BasicSchemeDST = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_"
+
+	// MessageAugmentationSchemeDST is the DST of the message augmentation
+	// scheme.
+	MessageAugmentationSchemeDST = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_AUG_"
+
+	// ProofOfPossessionSchemeDST is the DST of signatures in the proof of
+	// possession scheme.
+	ProofOfPossessionSchemeDST = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+
+	// ProofOfPossessionProofDST is the DST of proofs of possession in the
+	// proof of possession scheme.
+	ProofOfPossessionProofDST = "BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+// Domain separation tags of the min-pk ciphersuites.
+const (
+	// BasicSchemeMinPkDST is the DST of the min-pk basic scheme.
+	BasicSchemeMinPkDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"
+
+	// MessageAugmentationSchemeMinPkDST is the DST of the min-pk message
+	// augmentation scheme.
+	MessageAugmentationSchemeMinPkDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_"
+
+	// ProofOfPossessionSchemeMinPkDST is the DST of signatures in the
+	// min-pk proof of possession scheme.
+	ProofOfPossessionSchemeMinPkDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+	// ProofOfPossessionProofMinPkDST is the DST of proofs of possession in
+	// the min-pk proof of possession scheme.
+	ProofOfPossessionProofMinPkDST = "BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+// schemeLayout places public keys and signatures in G1 and G2. The
+// min-sig and min-pk layouts implement it.
+type schemeLayout interface {
+	publicKeySize() int
+	signatureSize() int
+	skToPk(sk *SecretKey) []byte
+	validatePublicKey(pk []byte) error
+	sign(sk *SecretKey, msg []byte, dst []byte) []byte
+	aggregateSignatures(sigs [][]byte) ([]byte, error)
+
+	// aggregateVerify checks that sig is the sum of signatures of msgs[i]
+	// by pks[i]. The public keys are validated.
+	aggregateVerify(pks [][]byte, msgs [][]byte, dst []byte, sig []byte) bool
+
+	// fastAggregateVerify checks that sig is the sum of signatures of msg
+	// by every public key. The public keys are only decoded.
+	fastAggregateVerify(pks [][]byte, msg []byte, dst []byte, sig []byte) bool
+}
+
+// minSigLayout puts signatures in G1 and public keys in G2.
+type minSigLayout struct{}
+
+func (minSigLayout) publicKeySize() int {
+	return G2CompressedSize
+}
+
+func (minSigLayout) signatureSize() int {
+	return G1CompressedSize
+}
+
+func (minSigLayout) skToPk(sk *SecretKey) []byte {
+	return encodeG2(G2ProjectiveOne.MulFR(sk.f).ToAffine())
+}
+
+func (minSigLayout) validatePublicKey(pk []byte) error {
+	_, err := decodePublicKeyG2(pk)
+	return err
+}
+
+// decodePublicKeyG2 decodes and validates a public key in G2.
+func decodePublicKeyG2(pk []byte) (*G2Affine, error) {
+	p, err := decodeG2(pk)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsZero() {
+		return nil, errors.New("public key is the point at infinity")
+	}
+	return p, nil
+}
+
+func (minSigLayout) sign(sk *SecretKey, msg []byte, dst []byte) []byte {
+	return encodeG1(HashToG1(msg, dst).MulFR(sk.f).ToAffine())
+}
+
+func (minSigLayout) aggregateSignatures(sigs [][]byte) ([]byte, error) {
+	agg := G1ProjectiveZero.Copy()
+	for _, sig := range sigs {
+		p, err := decodeG1(sig)
+		if err != nil {
+			return nil, err
+		}
+		agg = agg.AddAffine(p)
+	}
+	return encodeG1(agg.ToAffine()), nil
+}
+
+func (minSigLayout) aggregateVerify(pks [][]byte, msgs [][]byte, dst []byte, sig []byte) bool {
+	r, err := decodeG1(sig)
+	if err != nil {
+		return false
+	}
+
+	// e(sig, -g2) * prod e(H(m_i), pk_i) == 1
+	g1s := make([]*G1Projective, len(pks)+1)
+	g2s := make([]*G2Prepared, len(pks)+1)
+	g1s[0] = r.ToProjective()
+	g2s[0] = g2PreparedNegOne
+	for i := range pks {
+		p, err := decodePublicKeyG2(pks[i])
+		if err != nil {
+			return false
+		}
+		g1s[i+1] = HashToG1(msgs[i], dst)
+		g2s[i+1] = G2AffineToPrepared(p)
+	}
+	return pairingCheckPrepared(g1s, g2s)
+}
+
+func (minSigLayout) fastAggregateVerify(pks [][]byte, msg []byte, dst []byte, sig []byte) bool {
+	agg := G2ProjectiveZero.Copy()
+	for _, pk := range pks {
+		p, err := decodeG2(pk)
+		if err != nil {
+			return false
+		}
+		agg = agg.AddAffine(p)
+	}
+	r, err := decodeG1(sig)
+	if err != nil || agg.IsZero() {
+		return false
+	}
+	return pairingCheckPrepared(
+		[]*G1Projective{r.ToProjective(), HashToG1(msg, dst)},
+		[]*G2Prepared{g2PreparedNegOne, G2AffineToPrepared(agg.ToAffine())},
+	)
+}
+
+// minPkLayout puts public keys in G1 and signatures in G2.
+type minPkLayout struct{}
+
+// g1ProjectiveNegOne is the negated G1 generator.
+var g1ProjectiveNegOne = G1AffineOne.Neg().ToProjective()
+
+func (minPkLayout) publicKeySize() int {
+	return G1CompressedSize
+}
+
+func (minPkLayout) signatureSize() int {
+	return G2CompressedSize
+}
+
+func (minPkLayout) skToPk(sk *SecretKey) []byte {
+	return encodeG1(G1ProjectiveOne.MulFR(sk.f).ToAffine())
+}
+
+func (minPkLayout) validatePublicKey(pk []byte) error {
+	_, err := decodePublicKeyG1(pk)
+	return err
+}
+
+// decodePublicKeyG1 decodes and validates a public key in G1.
+func decodePublicKeyG1(pk []byte) (*G1Affine, error) {
+	p, err := decodeG1(pk)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsZero() {
+		return nil, errors.New("public key is the point at infinity")
+	}
+	return p, nil
+}
+
+func (minPkLayout) sign(sk *SecretKey, msg []byte, dst []byte) []byte {
+	return encodeG2(HashToG2(msg, dst).MulFR(sk.f).ToAffine())
+}
+
+func (minPkLayout) aggregateSignatures(sigs [][]byte) ([]byte, error) {
+	agg := G2ProjectiveZero.Copy()
+	for _, sig := range sigs {
+		p, err := decodeG2(sig)
+		if err != nil {
+			return nil, err
+		}
+		agg = agg.AddAffine(p)
+	}
+	return encodeG2(agg.ToAffine()), nil
+}
+
+func (minPkLayout) aggregateVerify(pks [][]byte, msgs [][]byte, dst []byte, sig []byte) bool {
+	r, err := decodeG2(sig)
+	if err != nil {
+		return false
+	}
+
+	// e(-g1, sig) * prod e(pk_i, H(m_i)) == 1
+	g1s := make([]*G1Projective, len(pks)+1)
+	g2s := make([]*G2Prepared, len(pks)+1)
+	g1s[0] = g1ProjectiveNegOne
+	g2s[0] = G2AffineToPrepared(r)
+	for i := range pks {
+		p, err := decodePublicKeyG1(pks[i])
+		if err != nil {
+			return false
+		}
+		g1s[i+1] = p.ToProjective()
+		g2s[i+1] = G2AffineToPrepared(HashToG2(msgs[i], dst).ToAffine())
+	}
+	return pairingCheckPrepared(g1s, g2s)
+}
+
+func (minPkLayout) fastAggregateVerify(pks [][]byte, msg []byte, dst []byte, sig []byte) bool {
+	agg := G1ProjectiveZero.Copy()
+	for _, pk := range pks {
+		p, err := decodeG1(pk)
+		if err != nil {
+			return false
+		}
+		agg = agg.AddAffine(p)
+	}
+	r, err := decodeG2(sig)
+	if err != nil || agg.IsZero() {
+		return false
+	}
+	return pairingCheckPrepared(
+		[]*G1Projective{g1ProjectiveNegOne, agg},
+		[]*G2Prepared{G2AffineToPrepared(r), G2AffineToPrepared(HashToG2(msg, dst).ToAffine())},
+	)
+}
+
+// coreScheme implements the core operations shared by all three schemes.
+type coreScheme struct {
+	layout schemeLayout
+	dst    []byte
+}
+
+// KeyGen derives a secret key from at least 32 bytes of secret input
+// keying material. It is KeyGen with an empty key_info.
+func (c coreScheme) KeyGen(ikm []byte) (*SecretKey, error) {
+	return KeyGen(ikm, nil)
+}
+
+// SkToPk returns the encoded public key of a secret key.
+func (c coreScheme) SkToPk(sk *SecretKey) []byte {
+	return c.layout.skToPk(sk)
+}
+
+// KeyValidate checks that a public key is a valid encoding of a point in
+// the public key group other than the point at infinity.
+func (c coreScheme) KeyValidate(pk []byte) bool {
+	return c.layout.validatePublicKey(pk) == nil
+}
+
+// PublicKeySize returns the size of an encoded public key.
+func (c coreScheme) PublicKeySize() int {
+	return c.layout.publicKeySize()
+}
+
+// SignatureSize returns the size of an encoded signature.
+func (c coreScheme) SignatureSize() int {
+	return c.layout.signatureSize()
+}
+
+// Aggregate adds up signatures. It fails if any of the signatures is not
+// a valid encoding of a point in the signature group.
+func (c coreScheme) Aggregate(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+	return c.layout.aggregateSignatures(sigs)
+}
+
+// coreSign signs a message.
+func (c coreScheme) coreSign(sk *SecretKey, msg []byte) []byte {
+	return c.layout.sign(sk, msg, c.dst)
+}
+
+// coreVerify verifies a signature of a message.
+func (c coreScheme) coreVerify(pk []byte, msg []byte, sig []byte) bool {
+	return c.coreAggregateVerify([][]byte{pk}, [][]byte{msg}, sig)
+}
+
+// coreAggregateVerify verifies an aggregate signature against a list of
+// public keys and the messages they signed.
+func (c coreScheme) coreAggregateVerify(pks [][]byte, msgs [][]byte, sig []byte) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	return c.layout.aggregateVerify(pks, msgs, c.dst, sig)
+}
+
+// BasicScheme is the basic scheme. It prevents rogue key attacks on
+// aggregate signatures by requiring all messages to be distinct.
+type BasicScheme struct {
+	coreScheme
+}
+
+// NewBasicScheme creates the min-sig basic scheme.
+func NewBasicScheme() *BasicScheme {
+	return &BasicScheme{coreScheme{minSigLayout{}, []byte(BasicSchemeDST)}}
+}
+
+// NewBasicSchemeMinPk creates the min-pk basic scheme.
+func NewBasicSchemeMinPk() *BasicScheme {
+	return &BasicScheme{coreScheme{minPkLayout{}, []byte(BasicSchemeMinPkDST)}}
+}
+
+// Sign signs a message.
+func (s *BasicScheme) Sign(sk *SecretKey, msg []byte) []byte {
+	return s.coreSign(sk, msg)
+}
+
+// Verify verifies a signature of a message.
+func (s *BasicScheme) Verify(pk []byte, msg []byte, sig []byte) bool {
+	return s.coreVerify(pk, msg, sig)
+}
+
+// AggregateVerify verifies an aggregate signature of distinct messages.
+// It returns false if any message appears twice.
+func (s *BasicScheme) AggregateVerify(pks [][]byte, msgs [][]byte, sig []byte) bool {
+	if hasDuplicates(msgs) {
+		return false
+	}
+	return s.coreAggregateVerify(pks, msgs, sig)
+}
+
+// MessageAugmentationScheme is the message augmentation scheme. It
+// prevents rogue key attacks by signing the public key together with the
+// message, so signers may sign the same message.
+type MessageAugmentationScheme struct {
+	coreScheme
+}
+
+// NewMessageAugmentationScheme creates the min-sig message augmentation
+// scheme.
+func NewMessageAugmentationScheme() *MessageAugmentationScheme {
+	return &MessageAugmentationScheme{coreScheme{minSigLayout{}, []byte(MessageAugmentationSchemeDST)}}
+}
+
+// NewMessageAugmentationSchemeMinPk creates the min-pk message
+// augmentation scheme.
+func NewMessageAugmentationSchemeMinPk() *MessageAugmentationScheme {
+	return &MessageAugmentationScheme{coreScheme{minPkLayout{}, []byte(MessageAugmentationSchemeMinPkDST)}}
+}
+
+// augment prepends a public key to a message.
+func augment(pk []byte, msg []byte) []byte {
+	return append(append([]byte{}, pk...), msg...)
+}
+
+// Sign signs a message prefixed with the signer's public key.
+func (s *MessageAugmentationScheme) Sign(sk *SecretKey, msg []byte) []byte {
+	return s.coreSign(sk, augment(s.SkToPk(sk), msg))
+}
+
+// Verify verifies a signature of a message.
+func (s *MessageAugmentationScheme) Verify(pk []byte, msg []byte, sig []byte) bool {
+	return s.coreVerify(pk, augment(pk, msg), sig)
+}
+
+// AggregateVerify verifies an aggregate signature of messages, each signed
+// by the corresponding public key.
+func (s *MessageAugmentationScheme) AggregateVerify(pks [][]byte, msgs [][]byte, sig []byte) bool {
+	if len(pks) != len(msgs) {
+		return false
+	}
+	augmented := make([][]byte, len(msgs))
+	for i := range msgs {
+		augmented[i] = augment(pks[i], msgs[i])
+	}
+	return s.coreAggregateVerify(pks, augmented, sig)
+}
+
+// ProofOfPossessionScheme is the proof of possession scheme. It prevents
+// rogue key attacks by requiring each signer to publish a proof that it
+// knows its secret key. Public keys must be checked with PopVerify before
+// they are used with FastAggregateVerify.
+type ProofOfPossessionScheme struct {
+	coreScheme
+	pop coreScheme
+}
+
+// NewProofOfPossessionScheme creates the min-sig proof of possession
+// scheme.
+func NewProofOfPossessionScheme() *ProofOfPossessionScheme {
+	return &ProofOfPossessionScheme{
+		coreScheme: coreScheme{minSigLayout{}, []byte(ProofOfPossessionSchemeDST)},
+		pop:        coreScheme{minSigLayout{}, []byte(ProofOfPossessionProofDST)},
+	}
+}
+
+// NewProofOfPossessionSchemeMinPk creates the min-pk proof of possession
+// scheme. This is the scheme used by the Ethereum consensus layer.
+func NewProofOfPossessionSchemeMinPk() *ProofOfPossessionScheme {
+	return &ProofOfPossessionScheme{
+		coreScheme: coreScheme{minPkLayout{}, []byte(ProofOfPossessionSchemeMinPkDST)},
+		pop:        coreScheme{minPkLayout{}, []byte(ProofOfPossessionProofMinPkDST)},
+	}
+}
+
+// Sign signs a message.
+func (s *ProofOfPossessionScheme) Sign(sk *SecretKey, msg []byte) []byte {
+	return s.coreSign(sk, msg)
+}
+
+// Verify verifies a signature of a message.
+func (s *ProofOfPossessionScheme) Verify(pk []byte, msg []byte, sig []byte) bool {
+	return s.coreVerify(pk, msg, sig)
+}
+
+// AggregateVerify verifies an aggregate signature of messages, each signed
+// by the corresponding public key. Unlike the basic scheme, the messages
+// need not be distinct.
+func (s *ProofOfPossessionScheme) AggregateVerify(pks [][]byte, msgs [][]byte, sig []byte) bool {
+	return s.coreAggregateVerify(pks, msgs, sig)
+}
+
+// PopProve creates a proof of possession of a secret key.
+func (s *ProofOfPossessionScheme) PopProve(sk *SecretKey) []byte {
+	return s.pop.coreSign(sk, s.SkToPk(sk))
+}
+
+// PopVerify verifies a proof of possession of the secret key of a public
+// key.
+func (s *ProofOfPossessionScheme) PopVerify(pk []byte, proof []byte) bool {
+	return s.pop.coreVerify(pk, pk, proof)
+}
+
+// FastAggregateVerify verifies an aggregate signature of a single message
+// signed by every public key. The proofs of possession of the public keys
+// must have been verified.
+func (s *ProofOfPossessionScheme) FastAggregateVerify(pks [][]byte, msg []byte, sig []byte) bool {
+	if len(pks) == 0 {
+		return false
+	}
+	return s.layout.fastAggregateVerify(pks, msg, s.dst, sig)
+}
@@ -0,0 +1,452 @@
+package bls_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/phoreproject/bls"
+	"golang.org/x/crypto/hkdf"
+)
+
+type schemeVector struct {
+	msg string
+	ikm string
+	sig string
+}
+
+// basicSchemeVectors are from the sig_g1_basic test vectors of the
+// draft-irtf-cfrg-bls-signature reference implementation (kwantam/bls_sigs_ref).
+// Their keys are derived with the KeyGen of draft-03, see keyGenDraft03.
+var basicSchemeVectors = []schemeVector{
+	{"ff624d0ba02c7b6370c1622eec3fa2186ea681d1659e0a845448e777b75a8e77a77bb26e5733179d58ef9bc8a4e8b6971aef2539f77ab0963a3415bbd6258339bd1bf55de65db520c63f5b8eab3d55debd05e9494212170f5d65b3286b8b668705b1e2b2b5568610617abb51d2dd0cb450ef59df4b907da90cfa7b268de8c4c2", "708309a7449e156b0db70e5b52e606c7e094ed676ce8953bf6c14757c826f590", "8376eaaae4275ee59263ba2a94c3e664c031bc3177eea3333ba893ab33c8df3f2e8825be3ada8ed6184b2e38367113ab"},
+	{"9155e91fd9155eeed15afd83487ea1a3af04c5998b77c0fe8c43dcc479440a8a9a89efe883d9385cb9edfde10b43bce61fb63669935ad39419cf29ef3a936931733bfc2378e253e73b7ae9a3ec7a6a7932ab10f1e5b94d05160c053988f3bdc9167155d069337d42c9a7056619efc031fa5ec7310d29bd28980b1e3559757578", "90c5386100b137a75b0bb495002b28697a451add2f1f22cb65f735e8aaeace98", "a1c9ab651facbb2687c61320d9e5a4d4ccbfe2f26742ff99ff893bb4eb6eb96bb6f0bbdedb8d3627951762482f7e5338"},
+	{"b242a7586a1383368a33c88264889adfa3be45422fbef4a2df4e3c5325a9c7757017e0d5cf4bbf4de7f99d189f81f1fd2f0dd645574d1eb0d547eead9375677819297c1abe62526ae29fc54cdd11bfe17714f2fbd2d0d0e8d297ff98535980482dd5c1ebdc5a7274aabf1382c9f2315ca61391e3943856e4c5e616c2f1f7be0d", "a3a43cece9c1abeff81099fb344d01f7d8df66447b95a667ee368f924bccf870", "89a0ee09fd60db04f311c603820d1c902d830f32d3d7f7ca3ff08d66b37f7d893de864f9c8f00ca6f4938aa53fdefbe4"},
+	{"b64005da76b24715880af94dba379acc25a047b06066c9bedc8f17b8c74e74f4fc720d9f4ef0e2a659e0756931c080587ebdcd0f85e819aea6dacb327a9d96496da53ea21aef3b2e793a9c0def5196acec99891f46ead78a85bc7ab644765781d3543da9fbf9fec916dca975ef3b4271e50ecc68bf79b2d8935e2b25fc063358", "7bbc8ff13f6f921f21e949b224c16b7176c5984d312b671cf6c2e4841135fc7f", "a26e6403e139228902d410cfcbfe47ddbbd28dfaf6dde53fb91e6248497d892f6008765d4a6e9c92b0e7fc550e06e4e8"},
+	{"fe6e1ea477640655eaa1f6e3352d4bce53eb3d95424df7f238e93d8531da8f36bc35fa6be4bf5a6a382e06e855139eb617a9cc9376b4dafacbd80876343b12628619d7cbe1bff6757e3706111ed53898c0219823adbc044eaf8c6ad449df8f6aab9d444dadb5c3380eec0d91694df5fc4b30280d4b87d27e67ae58a1df828963", "daf5ec7a4eebc20d9485796c355b4a65ad254fe19b998d0507e91ea24135f45d", "8c04c1cd94fe72bf7154ea7c2a1477e812e169d22c56b2bc9aa61b8b26357053cbf6fc34e23309ed3b5978982ef8ef5f"},
+	{"907c0c00dc080a688548957b5b8b1f33ba378de1368023dcad43242411f554eb7d392d3e5c1668fad3944ff9634105343d83b8c85d2a988da5f5dc60ee0518327caed6dd5cf4e9bc6222deb46d00abde745f9b71d6e7aee6c7fdfc9ed053f2c0b611d4c6863088bd012ea9810ee94f8e58905970ebd07353f1f409a371ed03e3", "8729a8396f262dabd991aa404cc1753581cea405f0d19222a0b3f210de8ee3c5", "a46914ee8c12ef7d5b6e929d0ef6660bd20415fe291dc3dcbd2279e3ccbd7ac8ffde0109484179f43a2b5d6f3571f6a3"},
+	{"771c4d7bce05610a3e71b272096b57f0d1efcce33a1cb4f714d6ebc0865b2773ec5eedc25fae81dee1d256474dbd9676623614c150916e6ed92ce4430b26037d28fa5252ef6b10c09dc2f7ee5a36a1ea7897b69f389d9f5075e271d92f4eb97b148f3abcb1e5be0b4feb8278613d18abf6da60bfe448238aa04d7f11b71f44c5", "f1b62413935fc589ad2280f6892599ad994dae8ca3655ed4f7318cc89b61aa96", "8b1f9376ceac50380d67715c92dd63b385f3aa3bd5f3e678c4b96d82557c211e9144db5adf49d71790586b7a68b7660e"},
+	{"a3b2825235718fc679b942e8ac38fb4f54415a213c65875b5453d18ca012320ddfbbc58b991eaebadfc2d1a28d4f0cd82652b12e4d5bfda89eda3be12ac52188e38e8cce32a264a300c0e463631f525ae501348594f980392c76b4a12ddc88e5ca086cb8685d03895919a8627725a3e00c4728e2b7c6f6a14fc342b2937fc3dd", "4caaa26f93f009682bbba6db6b265aec17b7ec1542bda458e8550b9e68eed18d", "ade10cba3965a3282b106cd2109a0fc74643f0143101a10fac2355effcc258b22940f6bce5b0b75faeafa8a4255f2af9"},
+	{"58ec2b2ceb80207ff51b17688bd5850f9388ce0b4a4f7316f5af6f52cfc4dde4192b6dbd97b56f93d1e4073517ac6c6140429b5484e266d07127e28b8e613ddf65888cbd5242b2f0eee4d5754eb11f25dfa5c3f87c790de371856c882731a157083a00d8eae29a57884dbbfcd98922c12cf5d73066daabe3bf3f42cfbdb9d853", "01d7bb864c5b5ecae019296cf9b5c63a166f5f1113942819b1933d889a96d12245777a99428f93de4fc9a18d709bf91889d7f8dddd522b4c364aeae13c983e9fae46", "91623db6536c60c2e2411a4d582c972694742eb3381a36e5d767e70b1c2a82d093dd5e21e04dba069365bee6a0075ef1"},
+	{"2449a53e0581f1b56d1e463b1c1686d33b3491efe1f3cc0443ba05d65694597cc7a2595bda9cae939166eb03cec624a788c9bbab69a39fb6554649131a56b26295683d8ac1aea969040413df405325425146c1e3a138d2f4f772ae2ed917cc36465acd66150058622440d7e77b3ad621e1c43a3f277da88d850d608079d9b911", "017e49b8ea8f9d1b7c0378e378a7a42e68e12cf78779ed41dcd29a090ae7e0f883b0d0f2cbc8f0473c0ad6732bea40d371a7f363bc6537d075bd1a4c23e558b0bc73", "b261e91006040bf53f1a8a6a732847d9c15755c571b6931ec6d09fe42d507d20f205d7ee62a04172633601f3e4933abd"},
+	{"7ba05797b5b67e1adfafb7fae20c0c0abe1543c94cee92d5021e1abc57720a6107999c70eacf3d4a79702cd4e6885fa1b7155398ac729d1ed6b45e51fe114c46caf444b20b406ad9cde6b9b2687aa645b46b51ab790b67047219e7290df1a797f35949aaf912a0a8556bb21018e7f70427c0fc018e461755378b981d0d9df3a9", "0135ea346852f837d10c1b2dfb8012ae8215801a7e85d4446dadd993c68d1e9206e1d8651b7ed763b95f707a52410eeef4f21ae9429828289eaea1fd9caadf826ace", "a8dc9b3c6ceb790e40fa5f3213f66018aa50fe03762d23011884f1a60bb0516b6ade4a67bd13b53eb17c2be60ac09980"},
+	{"716dabdb22a1c854ec60420249905a1d7ca68dd573efaff7542e76f0eae54a1828db69a39a1206cd05e10e681f24881b131e042ed9e19f5995c253840e937b809dfb8027fed71d541860f318691c13a2eb514daa5889410f256305f3b5b47cc16f7a7dad6359589b5f4568de4c4aae2357a8ea5e0ebaa5b89063eb3aa44eb952", "01393cb1ee9bfd7f7b9c057ecc66b43e807e12515f66ed7e9c9210ba1514693965988e567fbad7c3f17231aacee0e9b9a4b1940504b1cd4fd5edfaa62ba4e3e476fc", "8cd6647e4692328b2865987ce77e79b59f31c7e6fe1274dc9e4a702ed0e78437074b3d11224b8645b27ba36829a5c210"},
+}
+
+// basicSchemeMinPkVectors are from the sig_g2_basic test vectors of the
+// draft-irtf-cfrg-bls-signature reference implementation (kwantam/bls_sigs_ref).
+// Their keys are derived with the KeyGen of draft-03, see keyGenDraft03.
+var basicSchemeMinPkVectors = []schemeVector{
+	{"ff624d0ba02c7b6370c1622eec3fa2186ea681d1659e0a845448e777b75a8e77a77bb26e5733179d58ef9bc8a4e8b6971aef2539f77ab0963a3415bbd6258339bd1bf55de65db520c63f5b8eab3d55debd05e9494212170f5d65b3286b8b668705b1e2b2b5568610617abb51d2dd0cb450ef59df4b907da90cfa7b268de8c4c2", "708309a7449e156b0db70e5b52e606c7e094ed676ce8953bf6c14757c826f590", "b1341b7f4fbaa9228ae3b98b8c070c8758d67e111fc20f11a49fac426384b148722791589aaacb4a1d48ec93fe838bca1217078d6b4ae284d985c1081a622b32e8122612bc0bab3596d052e82b7562fd48f7b2c78ac344ee784fd5f53d5a00ad"},
+	{"9155e91fd9155eeed15afd83487ea1a3af04c5998b77c0fe8c43dcc479440a8a9a89efe883d9385cb9edfde10b43bce61fb63669935ad39419cf29ef3a936931733bfc2378e253e73b7ae9a3ec7a6a7932ab10f1e5b94d05160c053988f3bdc9167155d069337d42c9a7056619efc031fa5ec7310d29bd28980b1e3559757578", "90c5386100b137a75b0bb495002b28697a451add2f1f22cb65f735e8aaeace98", "b33d55ac59b8ac68291f25cf2ee53d8a3bb2c6e969ae3803308fe300158016d12ca5da94fd57f55e15416fb04d76e97004a38ef44f889e5f9d079f52786b33d8ecd66e03675b1cd4c785fe087c746b7003cb6cdd828ba1106cf7405cc4f0485f"},
+	{"b242a7586a1383368a33c88264889adfa3be45422fbef4a2df4e3c5325a9c7757017e0d5cf4bbf4de7f99d189f81f1fd2f0dd645574d1eb0d547eead9375677819297c1abe62526ae29fc54cdd11bfe17714f2fbd2d0d0e8d297ff98535980482dd5c1ebdc5a7274aabf1382c9f2315ca61391e3943856e4c5e616c2f1f7be0d", "a3a43cece9c1abeff81099fb344d01f7d8df66447b95a667ee368f924bccf870", "a3d39937ec047753c02c5fbc06a122a2491f55bbe4c5ef14f7c3d885fed4fdb12ab0cf6686f56d18054a90e82567c4630616f41b0beef580c589d52761380cbf208792b3ccefae457ed1487f03d0dbb2d78802b123ee9a6ae2c09466019ecb4f"},
+	{"b64005da76b24715880af94dba379acc25a047b06066c9bedc8f17b8c74e74f4fc720d9f4ef0e2a659e0756931c080587ebdcd0f85e819aea6dacb327a9d96496da53ea21aef3b2e793a9c0def5196acec99891f46ead78a85bc7ab644765781d3543da9fbf9fec916dca975ef3b4271e50ecc68bf79b2d8935e2b25fc063358", "7bbc8ff13f6f921f21e949b224c16b7176c5984d312b671cf6c2e4841135fc7f", "99aa38d3f78f1b15b3ccbd87f62a71f614398c151078c9f8bdfc97ff5073ecc06371340e67d1faeabb088ff9ff1f54ce043ae45c4dd92d46676dc20e2dfd092953b9bdb6126999b32431e4e1e7fff57ec12ac1ed361ae10dd4e44a013fa09a09"},
+	{"fe6e1ea477640655eaa1f6e3352d4bce53eb3d95424df7f238e93d8531da8f36bc35fa6be4bf5a6a382e06e855139eb617a9cc9376b4dafacbd80876343b12628619d7cbe1bff6757e3706111ed53898c0219823adbc044eaf8c6ad449df8f6aab9d444dadb5c3380eec0d91694df5fc4b30280d4b87d27e67ae58a1df828963", "daf5ec7a4eebc20d9485796c355b4a65ad254fe19b998d0507e91ea24135f45d", "b908c89c748618d15689651b50cb43c6a6e7b93d7d37f4b7d5e5f79415846dbff72824435cfbaf2400fc1af4dad4509c0fb67bf97bcc4a01c8838fe15e696339b9bf65a8fb4f8628bbb85bbf743195606b5a8afc5b18783dae3b27bc47d3aea9"},
+	{"907c0c00dc080a688548957b5b8b1f33ba378de1368023dcad43242411f554eb7d392d3e5c1668fad3944ff9634105343d83b8c85d2a988da5f5dc60ee0518327caed6dd5cf4e9bc6222deb46d00abde745f9b71d6e7aee6c7fdfc9ed053f2c0b611d4c6863088bd012ea9810ee94f8e58905970ebd07353f1f409a371ed03e3", "8729a8396f262dabd991aa404cc1753581cea405f0d19222a0b3f210de8ee3c5", "97f9699947778e450813c643f515fdde6efd436661f10a619041ca54a3bdbcd62b2d9007e050407c3c45bbe4c834abeb159dfdaecf5777b22368c9d2566c5602223970728cbb2fbc50ba5beb2e90ab878f032af6161677025cd96164e98ec797"},
+	{"771c4d7bce05610a3e71b272096b57f0d1efcce33a1cb4f714d6ebc0865b2773ec5eedc25fae81dee1d256474dbd9676623614c150916e6ed92ce4430b26037d28fa5252ef6b10c09dc2f7ee5a36a1ea7897b69f389d9f5075e271d92f4eb97b148f3abcb1e5be0b4feb8278613d18abf6da60bfe448238aa04d7f11b71f44c5", "f1b62413935fc589ad2280f6892599ad994dae8ca3655ed4f7318cc89b61aa96", "8133c5ca231de1545ffcc164b22283a28fd8af9725331609739e06ccba2618f70566d235a63129e24227fb5d53684eca0a7ddbdfe2effdc0d2d9f493c319770fbee6c5ce5657f4caea32478ea3c31aab45d504f28b056969389982c9a49ed6f1"},
+	{"a3b2825235718fc679b942e8ac38fb4f54415a213c65875b5453d18ca012320ddfbbc58b991eaebadfc2d1a28d4f0cd82652b12e4d5bfda89eda3be12ac52188e38e8cce32a264a300c0e463631f525ae501348594f980392c76b4a12ddc88e5ca086cb8685d03895919a8627725a3e00c4728e2b7c6f6a14fc342b2937fc3dd", "4caaa26f93f009682bbba6db6b265aec17b7ec1542bda458e8550b9e68eed18d", "afe4666c7f9fab588aa3ec30a6fcc9221f66da0399b43a6b3e918bef219ad65e236c42ebab243954fff24c27e94d498c00e1089dfbf7dfcc9f0b55d197483ebd0ebc0f1985eb958f32668f7fae067e22c4e472b034355b5b504a527e275b424a"},
+	{"58ec2b2ceb80207ff51b17688bd5850f9388ce0b4a4f7316f5af6f52cfc4dde4192b6dbd97b56f93d1e4073517ac6c6140429b5484e266d07127e28b8e613ddf65888cbd5242b2f0eee4d5754eb11f25dfa5c3f87c790de371856c882731a157083a00d8eae29a57884dbbfcd98922c12cf5d73066daabe3bf3f42cfbdb9d853", "01d7bb864c5b5ecae019296cf9b5c63a166f5f1113942819b1933d889a96d12245777a99428f93de4fc9a18d709bf91889d7f8dddd522b4c364aeae13c983e9fae46", "aae1905d01f781077e4ecb8f9a127335f3170fac18d5027e9a37296642cc5377b4921b153f6ccbf647e6e91a901fc331162aae3f48e28e90fd25f2fed45d431c24c04fd5766cb3db5121b22b530d96fefff89b48b093d648443eb629a4ffa7e6"},
+	{"2449a53e0581f1b56d1e463b1c1686d33b3491efe1f3cc0443ba05d65694597cc7a2595bda9cae939166eb03cec624a788c9bbab69a39fb6554649131a56b26295683d8ac1aea969040413df405325425146c1e3a138d2f4f772ae2ed917cc36465acd66150058622440d7e77b3ad621e1c43a3f277da88d850d608079d9b911", "017e49b8ea8f9d1b7c0378e378a7a42e68e12cf78779ed41dcd29a090ae7e0f883b0d0f2cbc8f0473c0ad6732bea40d371a7f363bc6537d075bd1a4c23e558b0bc73", "a0b1caefea99cb8f220bfd2c0171c390d2974aba31b830c3a1f0ce57088d088594d35a319179808f042a0b504d4c903d108786172335e79a7cffea45e66d0720f9a285c684fc8287e0c917a9841ce083687386627c2e0473d771bac63c1a6f18"},
+	{"7ba05797b5b67e1adfafb7fae20c0c0abe1543c94cee92d5021e1abc57720a6107999c70eacf3d4a79702cd4e6885fa1b7155398ac729d1ed6b45e51fe114c46caf444b20b406ad9cde6b9b2687aa645b46b51ab790b67047219e7290df1a797f35949aaf912a0a8556bb21018e7f70427c0fc018e461755378b981d0d9df3a9", "0135ea346852f837d10c1b2dfb8012ae8215801a7e85d4446dadd993c68d1e9206e1d8651b7ed763b95f707a52410eeef4f21ae9429828289eaea1fd9caadf826ace", "a7927e66eaec68919204effcc199557c2e56025ac5c896ba18d4a593eeb4cfbe8509506eee28c5a61d76f969381f0e3816194e6d7a9cbd422f79309d2049944a2fe4217b07b45d1cc810c8de9a9875f9712e11fbc3f7fdd7e7ad2989b231bcb4"},
+	{"716dabdb22a1c854ec60420249905a1d7ca68dd573efaff7542e76f0eae54a1828db69a39a1206cd05e10e681f24881b131e042ed9e19f5995c253840e937b809dfb8027fed71d541860f318691c13a2eb514daa5889410f256305f3b5b47cc16f7a7dad6359589b5f4568de4c4aae2357a8ea5e0ebaa5b89063eb3aa44eb952", "01393cb1ee9bfd7f7b9c057ecc66b43e807e12515f66ed7e9c9210ba1514693965988e567fbad7c3f17231aacee0e9b9a4b1940504b1cd4fd5edfaa62ba4e3e476fc", "b90b8a82bf6b620d615a4a915da8c7914322aaf5a1c2dbc529c0634760678287163bd70d35a93d0960484c0d1922c8800a19a8a6078929b3b84a010d8b6be5b97e27642fe0629268d459b4ef7783a749508ebe792079e67ef5523029266490ce"},
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// keyGenDraft03 is KeyGen as specified by draft-03, which uses the salt
+// for the first HKDF-Extract and only hashes it after a zero result. Later
+// drafts hash the salt first.
+func keyGenDraft03(ikm []byte) *bls.SecretKey {
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	ikmZero := append(append([]byte{}, ikm...), 0)
+	for {
+		okm := make([]byte, 48)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, ikmZero, salt, []byte{0, 48}), okm); err != nil {
+			panic(err)
+		}
+		sk := new(big.Int).Mod(new(big.Int).SetBytes(okm), bls.RFieldModulus)
+		if sk.Sign() != 0 {
+			return bls.KeyFromBig(sk)
+		}
+		h := sha256.Sum256(salt)
+		salt = h[:]
+	}
+}
+
+func testSchemeVectors(t *testing.T, scheme *bls.BasicScheme, vectors []schemeVector) {
+	for _, v := range vectors {
+		sk := keyGenDraft03(mustDecodeHex(v.ikm))
+		msg := mustDecodeHex(v.msg)
+		sig := scheme.Sign(sk, msg)
+		if hex.EncodeToString(sig) != v.sig {
+			t.Fatalf("signature of %s is %x, expected %s", v.msg, sig, v.sig)
+		}
+		if !scheme.Verify(scheme.SkToPk(sk), msg, sig) {
+			t.Fatalf("signature of %s did not verify", v.msg)
+		}
+	}
+}
+
+func TestBasicSchemeVectors(t *testing.T) {
+	testSchemeVectors(t, bls.NewBasicScheme(), basicSchemeVectors)
+}
+
+func TestBasicSchemeMinPkVectors(t *testing.T) {
+	testSchemeVectors(t, bls.NewBasicSchemeMinPk(), basicSchemeMinPkVectors)
+}
+
+// TestMessageAugmentationSchemeMinPkVectors checks the aggregate of
+// aggregates vector in src/test.cpp of the Chia bls-signatures library
+// (Chia-Network/bls-signatures), whose keys are derived with the KeyGen of
+// draft-03.
+func TestMessageAugmentationSchemeMinPkVectors(t *testing.T) {
+	scheme := bls.NewMessageAugmentationSchemeMinPk()
+	sk1 := keyGenDraft03(bytes.Repeat([]byte{2}, 32))
+	sk2 := keyGenDraft03(bytes.Repeat([]byte{3}, 32))
+	msg1 := []byte{1, 2, 3, 40}
+	msg2 := []byte{5, 6, 70, 201}
+	msg3 := []byte{9, 10, 11, 12, 13}
+	msg4 := []byte{15, 63, 244, 92, 0, 1}
+
+	sig1 := scheme.Sign(sk1, msg1)
+	if hex.EncodeToString(sig1) != "b1b2668924164f399fe9a1ee07a5a7120b683448c01bb88c3c048b8706e50d027e633e7b8f2492738e991cc0847c4949151e2ad95076f2bc6e44ed864ab093cc694fb167d7e3ea1c74ec99eb55c67d349dafafd6c302e5fa80c24746462c48de" {
+		t.Fatalf("unexpected signature %x", sig1)
+	}
+	if !scheme.Verify(scheme.SkToPk(sk1), msg1, sig1) {
+		t.Fatal("signature did not verify")
+	}
+
+	left, err := scheme.Aggregate([][]byte{sig1, scheme.Sign(sk2, msg2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	right, err := scheme.Aggregate([][]byte{scheme.Sign(sk2, msg1), scheme.Sign(sk1, msg3), scheme.Sign(sk1, msg4)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	agg, err := scheme.Aggregate([][]byte{left, right, scheme.Sign(sk1, msg1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(agg) != "a1d5360dcb418d33b29b90b912b4accde535cf0e52caf467a005dc632d9f7af44b6c4e9acd46eac218b28cdb07a3e3bc087df1cd1e3213aa4e11322a3ff3847bbba0b2fd19ddc25ca964871997b9bceeab37a4c2565876da19382ea32a962200" {
+		t.Fatalf("unexpected aggregate signature %x", agg)
+	}
+}
+
+// TestProofOfPossessionSchemeMinPkVectors checks the scheme against the
+// sign test vectors of the Ethereum consensus layer (ethereum/bls12-381-tests,
+// published as bls/sign/small/sign_case_*/data.yaml of the consensus spec
+// tests) and the proof of possession vector in src/test.cpp of the Chia
+// bls-signatures library.
+func TestProofOfPossessionSchemeMinPkVectors(t *testing.T) {
+	scheme := bls.NewProofOfPossessionSchemeMinPk()
+	sk := bls.DeserializeSecretKey(mustDecodeHex("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3"))
+	pk := scheme.SkToPk(sk)
+	if hex.EncodeToString(pk) != "a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a" {
+		t.Fatalf("unexpected public key %x", pk)
+	}
+
+	signatures := []struct {
+		sk  string
+		msg string
+		sig string
+	}{
+		{"263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3", "5656565656565656565656565656565656565656565656565656565656565656", "882730e5d03f6b42c3abc26d3372625034e1d871b65a8a6b900a56dae22da98abbe1b68f85e49fe7652a55ec3d0591c20767677e33e5cbb1207315c41a9ac03be39c2e7668edc043d6cb1d9fd93033caa8a1c5b0e84bedaeb6c64972503a43eb"},
+		{"263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3", "abababababababababababababababababababababababababababababababab", "91347bccf740d859038fcdcaf233eeceb2a436bcaaee9b2aa3bfb70efe29dfb2677562ccbea1c8e061fb9971b0753c240622fab78489ce96768259fc01360346da5b9f579e5da0d941e4c6ba18a0e64906082375394f337fa1af2b7127b0d121"},
+		{"263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3", "0000000000000000000000000000000000000000000000000000000000000000", "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55"},
+		{"47b8192d77bf871b62e87859d653922725724a5c031afeabc60bcef5ff665138", "5656565656565656565656565656565656565656565656565656565656565656", "af1390c3c47acdb37131a51216da683c509fce0e954328a59f93aebda7e4ff974ba208d9a4a2a2389f892a9d418d618418dd7f7a6bc7aa0da999a9d3a5b815bc085e14fd001f6a1948768a3f4afefc8b8240dda329f984cb345c6363272ba4fe"},
+		{"47b8192d77bf871b62e87859d653922725724a5c031afeabc60bcef5ff665138", "0000000000000000000000000000000000000000000000000000000000000000", "b23c46be3a001c63ca711f87a005c200cc550b9429d5f4eb38d74322144f1b63926da3388979e5321012fb1a0526bcd100b5ef5fe72628ce4cd5e904aeaa3279527843fae5ca9ca675f4f51ed8f83bbf7155da9ecc9663100a885d5dc6df96d9"},
+		{"328388aff0d4a5b7dc9205abd374e7e98f3cd9f3418edb4eafda5fb16473d216", "5656565656565656565656565656565656565656565656565656565656565656", "a4efa926610b8bd1c8330c918b7a5e9bf374e53435ef8b7ec186abf62e1b1f65aeaaeb365677ac1d1172a1f5b44b4e6d022c252c58486c0a759fbdc7de15a756acc4d343064035667a594b4c2a6f0b0b421975977f297dba63ee2f63ffe47bb6"},
+	}
+	for _, v := range signatures {
+		sk := bls.DeserializeSecretKey(mustDecodeHex(v.sk))
+		msg := mustDecodeHex(v.msg)
+		sig := scheme.Sign(sk, msg)
+		if hex.EncodeToString(sig) != v.sig {
+			t.Fatalf("signature of %s with %s is %x, expected %s", v.msg, v.sk, sig, v.sig)
+		}
+		if !scheme.Verify(scheme.SkToPk(sk), msg, sig) {
+			t.Fatalf("signature of %s with %s did not verify", v.msg, v.sk)
+		}
+	}
+
+	pubkeys := map[string]string{
+		"47b8192d77bf871b62e87859d653922725724a5c031afeabc60bcef5ff665138": "b301803f8b5ac4a1133581fc676dfedc60d891dd5fa99028805e5ea5b08d3491af75d0707adab3b70c6a6a580217bf81",
+		"328388aff0d4a5b7dc9205abd374e7e98f3cd9f3418edb4eafda5fb16473d216": "b53d21a4cfd562c469cc81514d4ce5a6b577d8403d32a394dc265dd190b47fa9f829fdd7963afdf972e5e77854051f6f",
+	}
+	for k, expected := range pubkeys {
+		pk := scheme.SkToPk(bls.DeserializeSecretKey(mustDecodeHex(k)))
+		if hex.EncodeToString(pk) != expected {
+			t.Fatalf("public key of %s is %x, expected %s", k, pk, expected)
+		}
+	}
+
+	popSK := keyGenDraft03(bytes.Repeat([]byte{4}, 32))
+	proof := scheme.PopProve(popSK)
+	if hex.EncodeToString(proof) != "84f709159435f0dc73b3e8bf6c78d85282d19231555a8ee3b6e2573aaf66872d9203fefa1ef700e34e7c3f3fb28210100558c6871c53f1ef6055b9f06b0d1abe22ad584ad3b957f3018a8f58227c6c716b1e15791459850f2289168fa0cf9115" {
+		t.Fatalf("unexpected proof of possession %x", proof)
+	}
+	if !scheme.PopVerify(scheme.SkToPk(popSK), proof) {
+		t.Fatal("proof of possession did not verify")
+	}
+}
+
+func testSkToPk(t *testing.T, pkOf func(*bls.SecretKey) []byte, expected []string) {
+	for i, e := range expected {
+		pk := pkOf(bls.KeyFromBig(big.NewInt(int64(i + 1))))
+		if hex.EncodeToString(pk) != e {
+			t.Fatalf("public key of %d is %x, expected %s", i+1, pk, e)
+		}
+	}
+}
+
+func TestSkToPk(t *testing.T) {
+	// multiples of the generators from the zkcrypto/bls12_381 test vectors
+	testSkToPk(t, bls.NewBasicScheme().SkToPk, []string{
+		"93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8",
+		"aa4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c335771638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a053",
+	})
+	testSkToPk(t, bls.NewBasicSchemeMinPk().SkToPk, []string{
+		"97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb",
+		"a572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e",
+	})
+}
+
+func testKeyValidate(t *testing.T, scheme *bls.BasicScheme) {
+	size := scheme.PublicKeySize()
+	pk := scheme.SkToPk(bls.KeyFromBig(big.NewInt(1)))
+	if len(pk) != size || !scheme.KeyValidate(pk) {
+		t.Fatal("public key of the generator is not valid")
+	}
+
+	infinity := make([]byte, size)
+	infinity[0] = 0xc0
+
+	uncompressed := append([]byte{}, pk...)
+	uncompressed[0] &= 0x7f
+
+	// x = q is not a canonical encoding
+	nonCanonical := make([]byte, size)
+	q := bls.QFieldModulus.Bytes()
+	copy(nonCanonical[48-len(q):], q)
+	nonCanonical[0] |= 0x80
+
+	// x = 0 is either not on the curve or not in the group
+	zero := make([]byte, size)
+	zero[0] = 0x80
+
+	invalid := map[string][]byte{
+		"infinity":      infinity,
+		"short":         pk[:size-1],
+		"uncompressed":  uncompressed,
+		"non-canonical": nonCanonical,
+		"zero":          zero,
+	}
+	for name, b := range invalid {
+		if scheme.KeyValidate(b) {
+			t.Errorf("%s public key is valid", name)
+		}
+	}
+}
+
+func TestKeyValidate(t *testing.T) {
+	testKeyValidate(t, bls.NewBasicScheme())
+	testKeyValidate(t, bls.NewBasicSchemeMinPk())
+}
+
+func testSignatureNotInSubgroup(t *testing.T, scheme *bls.BasicScheme, sig []byte) {
+	pk := scheme.SkToPk(bls.KeyFromBig(big.NewInt(5)))
+	if scheme.Verify(pk, []byte("message"), sig) {
+		t.Fatal("signature outside of the group verified")
+	}
+	if _, err := scheme.Aggregate([][]byte{sig}); err == nil {
+		t.Fatal("aggregated a signature outside of the group")
+	}
+}
+
+func TestSignatureNotInSubgroup(t *testing.T) {
+	var p1 *bls.G1Affine
+	for x := int64(1); p1 == nil || p1.IsInCorrectSubgroupAssumingOnCurve(); x++ {
+		p1 = bls.GetG1PointFromX(bls.NewFQ(big.NewInt(x)), false)
+	}
+	testSignatureNotInSubgroup(t, bls.NewBasicScheme(), bls.CompressG1(p1).Bytes())
+
+	// x = c0 + 0u is encoded as 48 zero bytes followed by c0
+	var p2 *bls.G2Affine
+	x := int64(0)
+	for p2 == nil || p2.IsInCorrectSubgroupAssumingOnCurve() {
+		x++
+		p2 = bls.GetG2PointFromX(bls.NewFQ2(bls.NewFQ(big.NewInt(x)), bls.FQZero), false)
+	}
+	sig := make([]byte, 96)
+	sig[0] = 0x80
+	sig[95] = byte(x)
+	testSignatureNotInSubgroup(t, bls.NewBasicSchemeMinPk(), sig)
+}
+
+func testBasicScheme(t *testing.T, scheme *bls.BasicScheme) {
+	r := NewXORShift(1)
+	sk1, _ := bls.RandKey(r)
+	sk2, _ := bls.RandKey(r)
+	pk1 := scheme.SkToPk(sk1)
+	pk2 := scheme.SkToPk(sk2)
+
+	msg1 := []byte("message 1")
+	msg2 := []byte("message 2")
+	sig1 := scheme.Sign(sk1, msg1)
+	sig2 := scheme.Sign(sk2, msg2)
+	if len(pk1) != scheme.PublicKeySize() || len(sig1) != scheme.SignatureSize() {
+		t.Fatal("unexpected public key or signature size")
+	}
+
+	if !scheme.Verify(pk1, msg1, sig1) {
+		t.Fatal("signature did not verify")
+	}
+	if scheme.Verify(pk2, msg1, sig1) {
+		t.Fatal("signature verified with the wrong key")
+	}
+	if scheme.Verify(pk1, msg2, sig1) {
+		t.Fatal("signature verified for the wrong message")
+	}
+
+	agg, err := scheme.Aggregate([][]byte{sig1, sig2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !scheme.AggregateVerify([][]byte{pk1, pk2}, [][]byte{msg1, msg2}, agg) {
+		t.Fatal("aggregate signature did not verify")
+	}
+	if scheme.AggregateVerify([][]byte{pk2, pk1}, [][]byte{msg1, msg2}, agg) {
+		t.Fatal("aggregate signature verified with swapped keys")
+	}
+
+	sig3 := scheme.Sign(sk2, msg1)
+	agg, _ = scheme.Aggregate([][]byte{sig1, sig3})
+	if scheme.AggregateVerify([][]byte{pk1, pk2}, [][]byte{msg1, msg1}, agg) {
+		t.Fatal("aggregate signature of duplicate messages verified")
+	}
+
+	if _, err := scheme.Aggregate(nil); err == nil {
+		t.Fatal("expected an error when aggregating no signatures")
+	}
+	if scheme.AggregateVerify(nil, nil, agg) {
+		t.Fatal("aggregate signature verified without keys")
+	}
+}
+
+func TestBasicScheme(t *testing.T) {
+	testBasicScheme(t, bls.NewBasicScheme())
+	testBasicScheme(t, bls.NewBasicSchemeMinPk())
+}
+
+func testMessageAugmentationScheme(t *testing.T, scheme *bls.MessageAugmentationScheme, basic *bls.BasicScheme) {
+	r := NewXORShift(2)
+	sk1, _ := bls.RandKey(r)
+	sk2, _ := bls.RandKey(r)
+	pk1 := scheme.SkToPk(sk1)
+	pk2 := scheme.SkToPk(sk2)
+
+	msg := []byte("message")
+	sig1 := scheme.Sign(sk1, msg)
+	sig2 := scheme.Sign(sk2, msg)
+	if !scheme.Verify(pk1, msg, sig1) {
+		t.Fatal("signature did not verify")
+	}
+	if scheme.Verify(pk2, msg, sig1) {
+		t.Fatal("signature verified with the wrong key")
+	}
+	if bytes.Equal(sig1, basic.Sign(sk1, msg)) {
+		t.Fatal("signature does not depend on the scheme")
+	}
+
+	agg, err := scheme.Aggregate([][]byte{sig1, sig2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !scheme.AggregateVerify([][]byte{pk1, pk2}, [][]byte{msg, msg}, agg) {
+		t.Fatal("aggregate signature of the same message did not verify")
+	}
+	if scheme.AggregateVerify([][]byte{pk1, pk2}, [][]byte{msg}, agg) {
+		t.Fatal("aggregate signature verified with a missing message")
+	}
+}
+
+func TestMessageAugmentationScheme(t *testing.T) {
+	testMessageAugmentationScheme(t, bls.NewMessageAugmentationScheme(), bls.NewBasicScheme())
+	testMessageAugmentationScheme(t, bls.NewMessageAugmentationSchemeMinPk(), bls.NewBasicSchemeMinPk())
+}
+
+func testProofOfPossessionScheme(t *testing.T, scheme *bls.ProofOfPossessionScheme) {
+	r := NewXORShift(3)
+
+	var pks [][]byte
+	var sigs [][]byte
+	msg := []byte("message")
+	for i := 0; i < 4; i++ {
+		sk, _ := bls.RandKey(r)
+		pk := scheme.SkToPk(sk)
+		proof := scheme.PopProve(sk)
+		if !scheme.PopVerify(pk, proof) {
+			t.Fatal("proof of possession did not verify")
+		}
+		if scheme.Verify(pk, pk, proof) {
+			t.Fatal("proof of possession verified as a signature")
+		}
+		if len(pks) > 0 && scheme.PopVerify(pks[0], proof) {
+			t.Fatal("proof of possession verified for the wrong key")
+		}
+		pks = append(pks, pk)
+		sigs = append(sigs, scheme.Sign(sk, msg))
+	}
+
+	agg, err := scheme.Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !scheme.FastAggregateVerify(pks, msg, agg) {
+		t.Fatal("fast aggregate signature did not verify")
+	}
+	if scheme.FastAggregateVerify(pks[1:], msg, agg) {
+		t.Fatal("fast aggregate signature verified with a missing key")
+	}
+	if scheme.FastAggregateVerify(nil, msg, agg) {
+		t.Fatal("fast aggregate signature verified without keys")
+	}
+	if !scheme.AggregateVerify(pks, [][]byte{msg, msg, msg, msg}, agg) {
+		t.Fatal("aggregate signature of the same message did not verify")
+	}
+}
+
+func TestProofOfPossessionScheme(t *testing.T) {
+	testProofOfPossessionScheme(t, bls.NewProofOfPossessionScheme())
+	testProofOfPossessionScheme(t, bls.NewProofOfPossessionSchemeMinPk())
+}
+
+func benchmarkSchemeVerify(b *testing.B, scheme *bls.BasicScheme) {
+	sk, _ := bls.RandKey(NewXORShift(1))
+	pk := scheme.SkToPk(sk)
+	msg := []byte("message")
+	sig := scheme.Sign(sk, msg)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		scheme.Verify(pk, msg, sig)
+	}
+}
+
+func BenchmarkBasicSchemeVerify(b *testing.B) {
+	benchmarkSchemeVerify(b, bls.NewBasicScheme())
+}
+
+func BenchmarkBasicSchemeMinPkVerify(b *testing.B) {
+	benchmarkSchemeVerify(b, bls.NewBasicSchemeMinPk())
+}
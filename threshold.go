@@ -0,0 +1,196 @@
+package bls
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// This file implements t-of-n threshold signatures. A secret key is split
+// into n shares with Shamir secret sharing: the key is the constant term
+// of a random polynomial of degree t-1 and share i is the polynomial at i.
+// Any t signature shares are combined into the group signature by
+// Lagrange interpolation in the exponent, and fewer reveal nothing about
+// the key.
+
+// SecretKeyShare is the share of a secret key held by one member of a
+// threshold group. Indices start at 1.
+type SecretKeyShare struct {
+	Index uint32
+	Key   *SecretKey
+}
+
+// PublicKeyShare is the public key of a secret key share.
+type PublicKeyShare struct {
+	Index     uint32
+	PublicKey *PublicKey
+}
+
+// SignatureShare is a signature made with a secret key share.
+type SignatureShare struct {
+	Index     uint32
+	Signature *Signature
+}
+
+// polynomial is a polynomial over FR, with the constant term first.
+type polynomial []*FR
+
+// randPolynomial creates a random polynomial with the given constant term.
+func randPolynomial(constant *FR, degree int, r io.Reader) (polynomial, error) {
+	p := make(polynomial, degree+1)
+	p[0] = constant.Copy()
+	for i := 1; i < len(p); i++ {
+		c, err := RandFR(r)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = c
+	}
+	return p, nil
+}
+
+// evaluate evaluates the polynomial at x with Horner's method.
+func (p polynomial) evaluate(x *FR) *FR {
+	out := FRZero.Copy()
+	for i := len(p) - 1; i >= 0; i-- {
+		out.MulAssign(x)
+		out.AddAssign(p[i])
+	}
+	return out
+}
+
+// frFromIndex converts a share index to a field element.
+func frFromIndex(index uint32) *FR {
+	return NewFR(new(big.Int).SetUint64(uint64(index)))
+}
+
+// checkThreshold checks the parameters of a t-of-n group.
+func checkThreshold(t int, n int) error {
+	if t < 1 || t > n {
+		return errors.New("threshold must be between 1 and the number of shares")
+	}
+	if uint64(n) > 1<<32-1 {
+		return errors.New("too many shares")
+	}
+	return nil
+}
+
+// SplitSecretKey splits a secret key into n shares, any t of which can
+// sign for the key. The polynomial coefficients are read from r.
+func SplitSecretKey(sk *SecretKey, t int, n int, r io.Reader) ([]*SecretKeyShare, error) {
+	if err := checkThreshold(t, n); err != nil {
+		return nil, err
+	}
+	p, err := randPolynomial(sk.f, t-1, r)
+	if err != nil {
+		return nil, err
+	}
+	return p.shares(n), nil
+}
+
+// shares evaluates the polynomial at 1 to n.
+func (p polynomial) shares(n int) []*SecretKeyShare {
+	shares := make([]*SecretKeyShare, n)
+	for i := range shares {
+		index := uint32(i + 1)
+		shares[i] = &SecretKeyShare{
+			Index: index,
+			Key:   &SecretKey{f: p.evaluate(frFromIndex(index))},
+		}
+	}
+	return shares
+}
+
+// PublicKeyShare returns the public key of a secret key share.
+func (s *SecretKeyShare) PublicKeyShare() *PublicKeyShare {
+	return &PublicKeyShare{Index: s.Index, PublicKey: PrivToPub(s.Key)}
+}
+
+// Sign signs a message with a secret key share.
+func (s *SecretKeyShare) Sign(message []byte, domain uint64) *SignatureShare {
+	return &SignatureShare{Index: s.Index, Signature: Sign(message, s.Key, domain)}
+}
+
+// VerifySignatureShare verifies a signature share against a message and
+// the public key share with the same index.
+func VerifySignatureShare(m []byte, pub *PublicKeyShare, sig *SignatureShare, domain uint64) bool {
+	if pub.Index != sig.Index {
+		return false
+	}
+	return Verify(m, pub.PublicKey, sig.Signature, domain)
+}
+
+// lagrangeCoefficients computes the Lagrange coefficients at zero of the
+// given indices. The indices must be nonzero and distinct.
+func lagrangeCoefficients(indices []uint32) ([]*FR, error) {
+	xs := make([]*FR, len(indices))
+	seen := make(map[uint32]bool, len(indices))
+	for i, index := range indices {
+		if index == 0 {
+			return nil, errors.New("share index must not be zero")
+		}
+		if seen[index] {
+			return nil, errors.New("duplicate share index")
+		}
+		seen[index] = true
+		xs[i] = frFromIndex(index)
+	}
+
+	// l_i(0) = prod x_j / (x_j - x_i) over j != i
+	coeffs := make([]*FR, len(xs))
+	for i := range xs {
+		num := FROne.Copy()
+		den := FROne.Copy()
+		for j := range xs {
+			if i != j {
+				num.MulAssign(xs[j])
+				den.MulAssign(xs[j].Sub(xs[i]))
+			}
+		}
+		coeffs[i] = num.Div(den)
+	}
+	return coeffs, nil
+}
+
+// RecoverSignature recovers the group signature from the first t
+// signature shares. The shares should be verified first, since a single
+// invalid share makes the result invalid.
+func RecoverSignature(shares []*SignatureShare, t int) (*Signature, error) {
+	if t < 1 || len(shares) < t {
+		return nil, errors.New("not enough signature shares")
+	}
+	indices := make([]uint32, t)
+	for i := range indices {
+		indices[i] = shares[i].Index
+	}
+	coeffs, err := lagrangeCoefficients(indices)
+	if err != nil {
+		return nil, err
+	}
+	sig := G1ProjectiveZero.Copy()
+	for i, c := range coeffs {
+		sig = sig.Add(shares[i].Signature.s.MulFR(c))
+	}
+	return &Signature{s: sig}, nil
+}
+
+// RecoverPublicKey recovers the group public key from the first t public
+// key shares.
+func RecoverPublicKey(shares []*PublicKeyShare, t int) (*PublicKey, error) {
+	if t < 1 || len(shares) < t {
+		return nil, errors.New("not enough public key shares")
+	}
+	indices := make([]uint32, t)
+	for i := range indices {
+		indices[i] = shares[i].Index
+	}
+	coeffs, err := lagrangeCoefficients(indices)
+	if err != nil {
+		return nil, err
+	}
+	pub := G2ProjectiveZero.Copy()
+	for i, c := range coeffs {
+		pub = pub.Add(shares[i].PublicKey.p.MulFR(c))
+	}
+	return &PublicKey{p: pub}, nil
+}
@@ -0,0 +1,128 @@
+package bls_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+func TestThresholdSignature(t *testing.T) {
+	r := NewXORShift(1)
+	sk, _ := bls.RandKey(r)
+	msg := []byte("threshold message")
+
+	shares, err := bls.SplitSecretKey(sk, 3, 5, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubShares := make([]*bls.PublicKeyShare, len(shares))
+	sigShares := make([]*bls.SignatureShare, len(shares))
+	for i, share := range shares {
+		if share.Index != uint32(i+1) {
+			t.Fatalf("share %d has index %d", i, share.Index)
+		}
+		pubShares[i] = share.PublicKeyShare()
+		sigShares[i] = share.Sign(msg, 0)
+		if !bls.VerifySignatureShare(msg, pubShares[i], sigShares[i], 0) {
+			t.Fatalf("signature share %d did not verify", i)
+		}
+	}
+
+	expected := bls.Sign(msg, sk, 0).Serialize()
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}} {
+		sigs := make([]*bls.SignatureShare, len(subset))
+		pubs := make([]*bls.PublicKeyShare, len(subset))
+		for i, j := range subset {
+			sigs[i] = sigShares[j]
+			pubs[i] = pubShares[j]
+		}
+
+		sig, err := bls.RecoverSignature(sigs, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(sig.Serialize(), expected) {
+			t.Fatalf("shares %v recovered the wrong signature", subset)
+		}
+
+		pub, err := bls.RecoverPublicKey(pubs, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pub.Equals(*bls.PrivToPub(sk)) {
+			t.Fatalf("shares %v recovered the wrong public key", subset)
+		}
+		if !bls.Verify(msg, pub, sig, 0) {
+			t.Fatal("recovered signature did not verify")
+		}
+	}
+
+	// t-1 shares interpolate a different polynomial
+	sig, err := bls.RecoverSignature(sigShares[:2], 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(sig.Serialize(), expected) {
+		t.Fatal("two shares recovered the signature of a 3-of-5 key")
+	}
+	if _, err := bls.RecoverSignature(sigShares[:2], 3); err == nil {
+		t.Fatal("recovered a signature from too few shares")
+	}
+}
+
+func TestVerifySignatureShare(t *testing.T) {
+	r := NewXORShift(2)
+	sk, _ := bls.RandKey(r)
+	msg := []byte("threshold message")
+	shares, _ := bls.SplitSecretKey(sk, 2, 3, r)
+
+	sig := shares[0].Sign(msg, 0)
+	if bls.VerifySignatureShare(msg, shares[1].PublicKeyShare(), sig, 0) {
+		t.Fatal("signature share verified against another share's key")
+	}
+	wrongIndex := &bls.SignatureShare{Index: 2, Signature: sig.Signature}
+	if bls.VerifySignatureShare(msg, shares[0].PublicKeyShare(), wrongIndex, 0) {
+		t.Fatal("signature share verified with the wrong index")
+	}
+	if bls.VerifySignatureShare([]byte("other message"), shares[0].PublicKeyShare(), sig, 0) {
+		t.Fatal("signature share verified for the wrong message")
+	}
+}
+
+func TestSplitSecretKeyParameters(t *testing.T) {
+	r := NewXORShift(3)
+	sk, _ := bls.RandKey(r)
+
+	for _, params := range [][2]int{{0, 3}, {4, 3}, {-1, 3}} {
+		if _, err := bls.SplitSecretKey(sk, params[0], params[1], r); err == nil {
+			t.Fatalf("%d-of-%d split was accepted", params[0], params[1])
+		}
+	}
+
+	// a 1-of-n split gives every member the key itself
+	shares, err := bls.SplitSecretKey(sk, 1, 3, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, share := range shares {
+		if !bytes.Equal(share.Key.Serialize(), sk.Serialize()) {
+			t.Fatal("1-of-n share is not the secret key")
+		}
+	}
+}
+
+func TestRecoverSignatureDuplicateIndex(t *testing.T) {
+	r := NewXORShift(4)
+	sk, _ := bls.RandKey(r)
+	shares, _ := bls.SplitSecretKey(sk, 2, 3, r)
+	sig := shares[0].Sign([]byte("message"), 0)
+
+	if _, err := bls.RecoverSignature([]*bls.SignatureShare{sig, sig}, 2); err == nil {
+		t.Fatal("recovered a signature from duplicate shares")
+	}
+	zero := &bls.SignatureShare{Index: 0, Signature: sig.Signature}
+	if _, err := bls.RecoverSignature([]*bls.SignatureShare{sig, zero}, 2); err == nil {
+		t.Fatal("recovered a signature from a share with index zero")
+	}
+}
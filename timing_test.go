@@ -0,0 +1,155 @@
+//go:build timing
+
+package bls_test
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/phoreproject/bls"
+)
+
+// The timing tests are statistical and slow, so they only run with the
+// timing build tag: go test -tags timing -run Timing
+
+// timingThreshold is the Welch's t statistic above which two timing
+// distributions are considered different. Leaky code like double-and-add
+// scores in the hundreds, while noise rarely pushes constant-time code
+// past single digits.
+const timingThreshold = 10
+
+// welchT computes Welch's t statistic for two samples after discarding the
+// slowest 10% of each, which are dominated by scheduler and GC noise.
+func welchT(a []float64, b []float64) float64 {
+	stats := func(s []float64) (float64, float64, float64) {
+		sort.Float64s(s)
+		s = s[:len(s)*9/10]
+		mean := 0.0
+		for _, x := range s {
+			mean += x
+		}
+		mean /= float64(len(s))
+		variance := 0.0
+		for _, x := range s {
+			variance += (x - mean) * (x - mean)
+		}
+		variance /= float64(len(s) - 1)
+		return mean, variance, float64(len(s))
+	}
+	meanA, varA, nA := stats(a)
+	meanB, varB, nB := stats(b)
+	return (meanA - meanB) / math.Sqrt(varA/nA+varB/nB)
+}
+
+// checkConstantTime times two classes of inputs to the same operation,
+// interleaving the runs so that drift in machine load affects both
+// equally, and fails if the running time depends on the class.
+func checkConstantTime(t *testing.T, samples int, class0 func(), class1 func()) {
+	times := [2][]float64{}
+	classes := [2]func(){class0, class1}
+	for i := 0; i < 2*samples; i++ {
+		c := i % 2
+		start := time.Now()
+		classes[c]()
+		times[c] = append(times[c], float64(time.Since(start)))
+	}
+	if tStat := welchT(times[0], times[1]); math.Abs(tStat) > timingThreshold {
+		t.Fatalf("running time depends on the input (t = %.1f)", tStat)
+	}
+}
+
+// lowAndHighWeightScalars returns a scalar with a single bit set and one
+// with almost every bit set.
+func lowAndHighWeightScalars() (*bls.FR, *bls.FR) {
+	return bls.FROne.Copy(), bls.FRZero.Sub(bls.FROne)
+}
+
+func TestTimingG1MulFR(t *testing.T) {
+	low, high := lowAndHighWeightScalars()
+	g := bls.G1ProjectiveOne.Copy()
+	checkConstantTime(t, 200,
+		func() { g.MulFR(low) },
+		func() { g.MulFR(high) },
+	)
+}
+
+func TestTimingG2MulFR(t *testing.T) {
+	low, high := lowAndHighWeightScalars()
+	g := bls.G2ProjectiveOne.Copy()
+	checkConstantTime(t, 50,
+		func() { g.MulFR(low) },
+		func() { g.MulFR(high) },
+	)
+}
+
+func TestTimingSign(t *testing.T) {
+	low, high := lowAndHighWeightScalars()
+	lowKey := bls.KeyFromBig(low.ToBig())
+	highKey := bls.KeyFromBig(high.ToBig())
+	msg := []byte("constant time")
+	checkConstantTime(t, 100,
+		func() { bls.Sign(msg, lowKey, 0) },
+		func() { bls.Sign(msg, highKey, 0) },
+	)
+}
+
+func TestTimingGTExp(t *testing.T) {
+	low, high := lowAndHighWeightScalars()
+	g := bls.PairingGT(bls.G1ProjectiveOne, bls.G2ProjectiveOne)
+	checkConstantTime(t, 50,
+		func() { g.Exp(low) },
+		func() { g.Exp(high) },
+	)
+}
+
+func TestTimingFQInverse(t *testing.T) {
+	small := bls.FQOne.Copy()
+	large := bls.FQOne.Neg()
+	checkConstantTime(t, 500,
+		func() { small.Inverse() },
+		func() { large.Inverse() },
+	)
+}
+
+func TestTimingFRInverse(t *testing.T) {
+	low, high := lowAndHighWeightScalars()
+	checkConstantTime(t, 500,
+		func() { low.Inverse() },
+		func() { high.Inverse() },
+	)
+}
+
+func TestTimingFRExp(t *testing.T) {
+	f := bls.NewFR(big.NewInt(5))
+	low, high := lowAndHighWeightScalars()
+	lowExp := low.ToBig()
+	highExp := high.ToBig()
+	checkConstantTime(t, 500,
+		func() { f.Exp(lowExp) },
+		func() { f.Exp(highExp) },
+	)
+}
+
+// TestTimingHarnessDetectsLeak makes sure the harness flags the
+// variable-time double-and-add multiplication.
+func TestTimingHarnessDetectsLeak(t *testing.T) {
+	low, high := lowAndHighWeightScalars()
+	lowBig := low.ToBig()
+	highBig := high.ToBig()
+	g := bls.G1ProjectiveOne.Copy()
+	times := [2][]float64{}
+	for i := 0; i < 100; i++ {
+		start := time.Now()
+		g.Mul(lowBig)
+		times[0] = append(times[0], float64(time.Since(start)))
+		start = time.Now()
+		g.Mul(highBig)
+		times[1] = append(times[1], float64(time.Since(start)))
+	}
+	if tStat := welchT(times[0], times[1]); math.Abs(tStat) <= timingThreshold {
+		t.Fatalf("harness did not detect variable-time multiplication (t = %.1f)", tStat)
+	}
+}
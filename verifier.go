@@ -0,0 +1,130 @@
+package bls
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// Verifier verifies signatures on several goroutines. The pairs of a
+// pairing check are split between the workers, which hash the messages,
+// prepare the public keys and run a Miller loop over their pairs. The
+// partial results are multiplied together and checked with a single final
+// exponentiation. A Verifier is safe to use from several goroutines, and
+// the zero value uses GOMAXPROCS workers.
+type Verifier struct {
+	workers int
+}
+
+// NewVerifier creates a verifier with the given number of workers. If
+// workers is zero or negative, it uses GOMAXPROCS workers.
+func NewVerifier(workers int) *Verifier {
+	return &Verifier{workers: workers}
+}
+
+// pairFunc computes the i-th pair of a pairing check.
+type pairFunc func(i int) (*G1Projective, *G2Prepared)
+
+// pairingCheck checks that the product of the pairings of n pairs is one.
+// It stops early and returns the context's error if ctx is cancelled.
+func (v *Verifier) pairingCheck(ctx context.Context, n int, pair pairFunc) (bool, error) {
+	workers := v.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indices := make(chan int, n)
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	partials := make([]*FQ12, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			var items []MillerLoopItem
+			for i := range indices {
+				if ctx.Err() != nil {
+					return
+				}
+				p, q := pair(i)
+				items = append(items, MillerLoopItem{P: p.ToAffine(), Q: q})
+			}
+			partials[w] = MillerLoop(items)
+		}(w)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	// the Miller loop of all pairs is the product of the partial loops
+	f := FQ12One.Copy()
+	for _, partial := range partials {
+		f.MulAssign(partial)
+	}
+	return FinalExponentiation(f).Equals(FQ12One), nil
+}
+
+// VerifyAggregate verifies each public key against each message like
+// Signature.VerifyAggregate.
+func (v *Verifier) VerifyAggregate(ctx context.Context, s *Signature, pubKeys []*PublicKey, msgs [][]byte, domain uint64) (bool, error) {
+	if len(pubKeys) != len(msgs) || hasDuplicates(msgs) {
+		return false, nil
+	}
+	return v.pairingCheck(ctx, len(pubKeys)+1, func(i int) (*G1Projective, *G2Prepared) {
+		if i == 0 {
+			return s.s, g2PreparedNegOne
+		}
+		return HashG1(msgs[i-1], domain), G2AffineToPrepared(pubKeys[i-1].p.ToAffine())
+	})
+}
+
+// VerifyAggregatePrepared verifies each prepared public key against each
+// message like Signature.VerifyAggregatePrepared.
+func (v *Verifier) VerifyAggregatePrepared(ctx context.Context, s *Signature, pubKeys []*PreparedPublicKey, msgs [][]byte, domain uint64) (bool, error) {
+	if len(pubKeys) != len(msgs) || hasDuplicates(msgs) {
+		return false, nil
+	}
+	return v.pairingCheck(ctx, len(pubKeys)+1, func(i int) (*G1Projective, *G2Prepared) {
+		if i == 0 {
+			return s.s, g2PreparedNegOne
+		}
+		return HashG1(msgs[i-1], domain), pubKeys[i-1].q
+	})
+}
+
+// BatchVerify verifies many signatures at once like the BatchVerify
+// function. The weights are read from r before the work is split, so r
+// does not need to be safe for concurrent use.
+func (v *Verifier) BatchVerify(ctx context.Context, sets []SignatureSet, weightBits int, r io.Reader) (bool, error) {
+	weights, err := batchWeights(len(sets), weightBits, r)
+	if err != nil {
+		return false, err
+	}
+	if len(sets) == 0 {
+		return true, nil
+	}
+
+	sig := G1ProjectiveZero.Copy()
+	for i, set := range sets {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		sig = sig.Add(set.Signature.s.Mul(weights[i]))
+	}
+	return v.pairingCheck(ctx, len(sets)+1, func(i int) (*G1Projective, *G2Prepared) {
+		if i == 0 {
+			return sig, g2PreparedNegOne
+		}
+		set := sets[i-1]
+		return HashG1(set.Message, set.Domain).Mul(weights[i-1]), G2AffineToPrepared(set.PublicKey.p.ToAffine())
+	})
+}
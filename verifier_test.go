@@ -0,0 +1,150 @@
+package bls_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+func aggregateSignatureSets(n int, seed uint64) (*bls.Signature, []*bls.PublicKey, [][]byte) {
+	r := NewXORShift(seed)
+	sigs := make([]*bls.Signature, n)
+	pubs := make([]*bls.PublicKey, n)
+	msgs := make([][]byte, n)
+	for i := range sigs {
+		priv, _ := bls.RandKey(r)
+		pubs[i] = bls.PrivToPub(priv)
+		msgs[i] = []byte(fmt.Sprintf("Hello world! 16 characters %d", i))
+		sigs[i] = bls.Sign(msgs[i], priv, 0)
+	}
+	return bls.AggregateSignatures(sigs), pubs, msgs
+}
+
+func TestVerifierVerifyAggregate(t *testing.T) {
+	ctx := context.Background()
+	sig, pubs, msgs := aggregateSignatureSets(10, 1)
+	prepared := make([]*bls.PreparedPublicKey, len(pubs))
+	for i := range pubs {
+		prepared[i] = bls.NewPreparedPublicKey(pubs[i])
+	}
+
+	for _, v := range []*bls.Verifier{bls.NewVerifier(0), bls.NewVerifier(1), bls.NewVerifier(3), {}} {
+		valid, err := v.VerifyAggregate(ctx, sig, pubs, msgs, 0)
+		if err != nil || !valid {
+			t.Fatal("aggregate signature did not verify")
+		}
+		valid, err = v.VerifyAggregatePrepared(ctx, sig, prepared, msgs, 0)
+		if err != nil || !valid {
+			t.Fatal("aggregate signature did not verify with prepared keys")
+		}
+		valid, err = v.VerifyAggregate(ctx, sig, pubs, msgs, 1)
+		if err != nil || valid {
+			t.Fatal("aggregate signature verified with the wrong domain")
+		}
+		valid, err = v.VerifyAggregate(ctx, sig, pubs[1:], msgs[1:], 0)
+		if err != nil || valid {
+			t.Fatal("aggregate signature verified with a missing key")
+		}
+		valid, err = v.VerifyAggregate(ctx, sig, pubs, append([][]byte{msgs[1]}, msgs[1:]...), 0)
+		if err != nil || valid {
+			t.Fatal("aggregate signature verified with duplicate messages")
+		}
+	}
+}
+
+func TestVerifierBatchVerify(t *testing.T) {
+	ctx := context.Background()
+	r := NewXORShift(2)
+	sets := batchSignatureSets(10, 3)
+	v := bls.NewVerifier(4)
+
+	valid, err := v.BatchVerify(ctx, sets, 64, r)
+	if err != nil || !valid {
+		t.Fatal("valid batch did not verify")
+	}
+	valid, err = v.BatchVerify(ctx, nil, 64, r)
+	if err != nil || !valid {
+		t.Fatal("empty batch did not verify")
+	}
+
+	sets[9].Message = []byte("wrong message")
+	valid, err = v.BatchVerify(ctx, sets, 64, r)
+	if err != nil || valid {
+		t.Fatal("batch with an invalid signature verified")
+	}
+}
+
+func TestVerifierCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sig, pubs, msgs := aggregateSignatureSets(4, 4)
+	v := bls.NewVerifier(2)
+	if _, err := v.VerifyAggregate(ctx, sig, pubs, msgs, 0); err != context.Canceled {
+		t.Fatalf("VerifyAggregate returned %v for a cancelled context", err)
+	}
+	if _, err := v.BatchVerify(ctx, batchSignatureSets(4, 5), 64, NewXORShift(6)); err != context.Canceled {
+		t.Fatalf("BatchVerify returned %v for a cancelled context", err)
+	}
+}
+
+func benchmarkVerifierVerifyAggregate(b *testing.B, n int, workers int) {
+	ctx := context.Background()
+	sig, pubs, msgs := aggregateSignatureSets(n, 7)
+	v := bls.NewVerifier(workers)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.VerifyAggregate(ctx, sig, pubs, msgs, 0)
+	}
+}
+
+func BenchmarkVerifyAggregate128(b *testing.B) {
+	sig, pubs, msgs := aggregateSignatureSets(128, 7)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sig.VerifyAggregate(pubs, msgs, 0)
+	}
+}
+
+func BenchmarkVerifierVerifyAggregate128Workers1(b *testing.B) {
+	benchmarkVerifierVerifyAggregate(b, 128, 1)
+}
+
+func BenchmarkVerifierVerifyAggregate128(b *testing.B) {
+	benchmarkVerifierVerifyAggregate(b, 128, 0)
+}
+
+func BenchmarkVerifierVerifyAggregate16(b *testing.B) {
+	benchmarkVerifierVerifyAggregate(b, 16, 0)
+}
+
+func BenchmarkVerifierBatchVerify128(b *testing.B) {
+	ctx := context.Background()
+	r := NewXORShift(8)
+	sets := batchSignatureSets(128, 9)
+	v := bls.NewVerifier(0)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.BatchVerify(ctx, sets, 64, r)
+	}
+}
+
+// BenchmarkVerifierParallel runs many aggregate verifications at once on
+// a shared verifier, so the workers compete for the cores.
+func BenchmarkVerifierParallel(b *testing.B) {
+	ctx := context.Background()
+	sig, pubs, msgs := aggregateSignatureSets(16, 10)
+	v := bls.NewVerifier(0)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			v.VerifyAggregate(ctx, sig, pubs, msgs, 0)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package bls
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// This file implements Feldman verifiable secret sharing. The dealer of a
+// Shamir secret sharing publishes the coefficients of the polynomial
+// multiplied by the G2 generator. Anyone can then compute the public key
+// of every share from the commitment, and each member can check that the
+// share they received lies on the committed polynomial. The first
+// commitment is the public key of the shared secret key.
+
+// VSSCommitment is a Feldman commitment to a secret sharing polynomial.
+type VSSCommitment struct {
+	coeffs []*G2Projective
+}
+
+// commit computes the commitment to a polynomial.
+func (p polynomial) commit() *VSSCommitment {
+	coeffs := make([]*G2Projective, len(p))
+	for i, c := range p {
+		coeffs[i] = G2ProjectiveOne.MulFR(c)
+	}
+	return &VSSCommitment{coeffs: coeffs}
+}
+
+// SplitSecretKeyVerifiable splits a secret key into n shares like
+// SplitSecretKey and returns the commitment the shares can be verified
+// against.
+func SplitSecretKeyVerifiable(sk *SecretKey, t int, n int, r io.Reader) ([]*SecretKeyShare, *VSSCommitment, error) {
+	if err := checkThreshold(t, n); err != nil {
+		return nil, nil, err
+	}
+	p, err := randPolynomial(sk.f, t-1, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.shares(n), p.commit(), nil
+}
+
+// Threshold returns the number of shares needed to recover the secret.
+func (c *VSSCommitment) Threshold() int {
+	return len(c.coeffs)
+}
+
+// PublicKey returns the public key of the shared secret key.
+func (c *VSSCommitment) PublicKey() *PublicKey {
+	return &PublicKey{p: c.coeffs[0].Copy()}
+}
+
+// PublicKeyShare computes the public key of the share with the given
+// index from the commitment.
+func (c *VSSCommitment) PublicKeyShare(index uint32) *PublicKeyShare {
+	x := new(big.Int).SetUint64(uint64(index))
+	pub := G2ProjectiveZero.Copy()
+	for i := len(c.coeffs) - 1; i >= 0; i-- {
+		pub = pub.Mul(x).Add(c.coeffs[i])
+	}
+	return &PublicKeyShare{Index: index, PublicKey: &PublicKey{p: pub}}
+}
+
+// VerifyShare checks that a secret key share lies on the committed
+// polynomial.
+func (c *VSSCommitment) VerifyShare(share *SecretKeyShare) bool {
+	if share == nil || share.Key == nil || share.Index == 0 {
+		return false
+	}
+	return PrivToPub(share.Key).p.Equal(c.PublicKeyShare(share.Index).PublicKey.p)
+}
+
+// scale multiplies the committed polynomial by a scalar.
+func (c *VSSCommitment) scale(s *FR) *VSSCommitment {
+	coeffs := make([]*G2Projective, len(c.coeffs))
+	for i, p := range c.coeffs {
+		coeffs[i] = p.MulFR(s)
+	}
+	return &VSSCommitment{coeffs: coeffs}
+}
+
+// Serialize serializes the commitment as its compressed points, in the
+// same form as PublicKey.Serialize.
+func (c *VSSCommitment) Serialize() []byte {
+	out := make([]byte, 0, len(c.coeffs)*96)
+	for _, p := range c.coeffs {
+		out = append(out, PublicKey{p: p}.Serialize()...)
+	}
+	return out
+}
+
+// DeserializeVSSCommitment deserializes a commitment.
+func DeserializeVSSCommitment(b []byte) (*VSSCommitment, error) {
+	if len(b) == 0 || len(b)%96 != 0 {
+		return nil, errors.New("commitment has the wrong length")
+	}
+	coeffs := make([]*G2Projective, len(b)/96)
+	for i := range coeffs {
+		pub, err := DeserializePublicKey(b[i*96 : (i+1)*96])
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = pub.p
+	}
+	return &VSSCommitment{coeffs: coeffs}, nil
+}
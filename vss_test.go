@@ -0,0 +1,93 @@
+package bls_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/phoreproject/bls"
+)
+
+func TestVSSCommitment(t *testing.T) {
+	r := NewXORShift(1)
+	sk, _ := bls.RandKey(r)
+
+	shares, commitment, err := bls.SplitSecretKeyVerifiable(sk, 3, 5, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commitment.Threshold() != 3 {
+		t.Fatalf("commitment has threshold %d, expected 3", commitment.Threshold())
+	}
+	if !commitment.PublicKey().Equals(*bls.PrivToPub(sk)) {
+		t.Fatal("commitment does not commit to the public key")
+	}
+
+	for _, share := range shares {
+		if !commitment.VerifyShare(share) {
+			t.Fatalf("share %d did not verify", share.Index)
+		}
+		pub := commitment.PublicKeyShare(share.Index)
+		if pub.Index != share.Index || !pub.PublicKey.Equals(*share.PublicKeyShare().PublicKey) {
+			t.Fatalf("commitment derived the wrong public key for share %d", share.Index)
+		}
+	}
+}
+
+func TestVSSCommitmentInvalidShare(t *testing.T) {
+	r := NewXORShift(2)
+	sk, _ := bls.RandKey(r)
+	shares, commitment, _ := bls.SplitSecretKeyVerifiable(sk, 2, 3, r)
+
+	other, _ := bls.RandKey(r)
+	bad := &bls.SecretKeyShare{Index: 1, Key: other}
+	if commitment.VerifyShare(bad) {
+		t.Fatal("share with the wrong key verified")
+	}
+	moved := &bls.SecretKeyShare{Index: 3, Key: shares[0].Key}
+	if commitment.VerifyShare(moved) {
+		t.Fatal("share with the wrong index verified")
+	}
+	zero := &bls.SecretKeyShare{Index: 0, Key: sk}
+	if commitment.VerifyShare(zero) {
+		t.Fatal("share with index zero verified")
+	}
+	if commitment.VerifyShare(nil) || commitment.VerifyShare(&bls.SecretKeyShare{Index: 1}) {
+		t.Fatal("share without a key verified")
+	}
+
+	// shares of another split do not match the commitment
+	otherShares, _, _ := bls.SplitSecretKeyVerifiable(sk, 2, 3, r)
+	if commitment.VerifyShare(otherShares[1]) {
+		t.Fatal("share of another polynomial verified")
+	}
+}
+
+func TestVSSCommitmentSerializeDeserialize(t *testing.T) {
+	r := NewXORShift(3)
+	sk, _ := bls.RandKey(r)
+	shares, commitment, _ := bls.SplitSecretKeyVerifiable(sk, 4, 6, r)
+
+	b := commitment.Serialize()
+	if len(b) != 4*96 {
+		t.Fatalf("serialized commitment has %d bytes, expected %d", len(b), 4*96)
+	}
+	commitment2, err := bls.DeserializeVSSCommitment(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(commitment2.Serialize(), b) {
+		t.Fatal("commitment changed after serializing and deserializing")
+	}
+	for _, share := range shares {
+		if !commitment2.VerifyShare(share) {
+			t.Fatal("share did not verify against the deserialized commitment")
+		}
+	}
+
+	if _, err := bls.DeserializeVSSCommitment(b[:100]); err == nil {
+		t.Fatal("truncated commitment was accepted")
+	}
+	if _, err := bls.DeserializeVSSCommitment(nil); err == nil {
+		t.Fatal("empty commitment was accepted")
+	}
+}